@@ -13,6 +13,8 @@ type configuration struct {
 	logger        *logger.Config
 	discord       *discord.Config
 	configuration *string
+	automod       *string
+	dryRun        *bool
 }
 
 func newConfiguration() configuration {
@@ -23,6 +25,8 @@ func newConfiguration() configuration {
 		logger:        logger.Flags(fs, "logger"),
 		discord:       discord.Flags(fs, ""),
 		configuration: flags.New("", "Configuration of commands, as JSON string").Prefix("commands").String(fs, "", nil),
+		automod:       flags.New("", "AutoModeration rules by name, as JSON string").Prefix("automod").String(fs, "", nil),
+		dryRun:        flags.New("dryRun", "Print AutoModeration changes without applying them").Prefix("automod").Bool(fs, false, nil),
 	}
 
 	_ = fs.Parse(os.Args[1:])
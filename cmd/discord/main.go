@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"os"
 
@@ -20,14 +21,43 @@ func main() {
 	services, err := newServices(config)
 	logger.FatalfOnErr(ctx, err, "services")
 
-	var commands map[string]discord.Command
-	if err := json.Unmarshal([]byte(*config.configuration), &commands); err != nil {
-		slog.LogAttrs(ctx, slog.LevelError, "parse configuration", slog.Any("error", err))
-		os.Exit(1)
+	if len(*config.configuration) != 0 {
+		var commands map[string]discord.Command
+		if err := json.Unmarshal([]byte(*config.configuration), &commands); err != nil {
+			slog.LogAttrs(ctx, slog.LevelError, "parse configuration", slog.Any("error", err))
+			os.Exit(1)
+		}
+
+		if err := services.discord.ConfigureCommands(ctx, commands); err != nil {
+			slog.LogAttrs(ctx, slog.LevelError, "configure command", slog.Any("error", err))
+			os.Exit(1)
+		}
 	}
 
-	if err := services.discord.ConfigureCommands(ctx, commands); err != nil {
-		slog.LogAttrs(ctx, slog.LevelError, "configure command", slog.Any("error", err))
-		os.Exit(1)
+	if len(*config.automod) != 0 {
+		if err := syncAutoModeration(ctx, services.discord, *config.automod, *config.dryRun); err != nil {
+			slog.LogAttrs(ctx, slog.LevelError, "sync automod", slog.Any("error", err))
+			os.Exit(1)
+		}
 	}
 }
+
+func syncAutoModeration(ctx context.Context, service discord.Service, configuration string, dryRun bool) error {
+	var rules map[string]discord.AutoModerationRule
+	if err := json.Unmarshal([]byte(configuration), &rules); err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+
+	req, err := service.SigninClient(ctx, "bot")
+	if err != nil {
+		return fmt.Errorf("signin: %w", err)
+	}
+
+	changes, err := service.SyncAutoModerationRules(ctx, req, rules, dryRun)
+
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	return err
+}
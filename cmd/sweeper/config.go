@@ -13,8 +13,12 @@ type configuration struct {
 	logger  *logger.Config
 	discord *discord.Config
 
-	userIDs   *[]string
-	usernames *[]string
+	userIDs      *[]string
+	usernames    *[]string
+	reason       *string
+	keepPinned   *bool
+	interactions *bool
+	mentions     *bool
 }
 
 func newConfiguration() configuration {
@@ -25,8 +29,12 @@ func newConfiguration() configuration {
 		logger:  logger.Flags(fs, "logger"),
 		discord: discord.Flags(fs, ""),
 
-		userIDs:   flags.New("user", "User ID to clean").DocPrefix("sweeper").StringSlice(fs, nil, nil),
-		usernames: flags.New("username", "Username to clean").DocPrefix("sweeper").StringSlice(fs, nil, nil),
+		userIDs:      flags.New("user", "User ID to clean").DocPrefix("sweeper").StringSlice(fs, nil, nil),
+		usernames:    flags.New("username", "Username to clean").DocPrefix("sweeper").StringSlice(fs, nil, nil),
+		reason:       flags.New("reason", "Reason displayed in the audit log").DocPrefix("sweeper").String(fs, "Sweeping old messages", nil),
+		keepPinned:   flags.New("keepPinned", "Keep pinned messages").DocPrefix("sweeper").Bool(fs, false, nil),
+		interactions: flags.New("interactions", "Also clean bot messages answering an interaction of the user").DocPrefix("sweeper").Bool(fs, false, nil),
+		mentions:     flags.New("mentions", "Also clean bot messages mentioning the user").DocPrefix("sweeper").Bool(fs, false, nil),
 	}
 
 	_ = fs.Parse(os.Args[1:])
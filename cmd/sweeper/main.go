@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -49,7 +50,29 @@ func main() {
 			channels, err := discord.Channels(ctx, req, guild)
 			logger.FatalfOnErr(ctx, err, "channels")
 
+			threads, err := services.discord.ActiveThreads(ctx, req, guild.ID)
+			if err != nil {
+				slog.Error("list active threads", slog.String("guild", guild.Name), slog.Any("error", err))
+			}
+
 			for _, channel := range channels {
+				if !channel.HasThreads() {
+					continue
+				}
+
+				archived, err := services.discord.ArchivedThreads(ctx, req, channel.ID, false)
+				if err != nil {
+					slog.Error("list archived threads", slog.String("guild", guild.Name), slog.String("channel", channel.Name), slog.Any("error", err))
+				}
+
+				threads = append(threads, archived...)
+			}
+
+			for _, channel := range append(channels, threads...) {
+				if !channel.HasMessages() {
+					continue
+				}
+
 				if err := services.discord.Messages(ctx, req, channel.ID, messagesCh); err != nil {
 					slog.Error("list messages", slog.String("guild", guild.Name), slog.String("channel", channel.Name), slog.Any("error", err))
 				}
@@ -66,8 +89,12 @@ func main() {
 			continue
 		}
 
-		if shouldDelete(message, *config.userIDs, *config.usernames) {
-			if err := services.discord.DeleteMessage(ctx, req, message); err != nil {
+		if *config.keepPinned && message.Pinned {
+			continue
+		}
+
+		if shouldDelete(message, *config.userIDs, *config.usernames, *config.interactions, *config.mentions) {
+			if err := services.discord.DeleteMessage(discord.WithAuditLogReason(ctx, *config.reason), req, message); err != nil {
 				slog.ErrorContext(ctx, "unable to delete delete message", slog.Any("error", err))
 			} else {
 				deleted++
@@ -78,9 +105,21 @@ func main() {
 	slog.InfoContext(ctx, fmt.Sprintf("%d messages read, %d deleted", read, deleted))
 }
 
-func shouldDelete(message discord.Message, userIDs, usernames []string) bool {
+func shouldDelete(message discord.Message, userIDs, usernames []string, interactions, mentions bool) bool {
 	for _, userID := range userIDs {
-		if message.Author.Bot && strings.Contains(message.Content, userID) {
+		if !message.Author.Bot {
+			continue
+		}
+
+		if strings.Contains(message.Content, userID) {
+			return true
+		}
+
+		if interactions && message.InteractionMetadata != nil && message.InteractionMetadata.User.ID == userID {
+			return true
+		}
+
+		if mentions && slices.ContainsFunc(message.Mentions, func(user discord.User) bool { return user.ID == userID }) {
 			return true
 		}
 	}
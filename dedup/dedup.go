@@ -0,0 +1,119 @@
+package dedup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// DefaultTTL matches the lifetime of an interaction token, after which a retry can't happen anymore
+const DefaultTTL = 15 * time.Minute
+
+// ErrNotFound is returned by a Store when the key is unknown or expired
+var ErrNotFound = errors.New("key not found")
+
+var pending = []byte("pending")
+
+// Store remembers the requests already received, with their response
+type Store interface {
+	// SaveIfAbsent atomically saves the content only if the key is unknown, reporting if it did
+	SaveIfAbsent(ctx context.Context, key string, content []byte, ttl time.Duration) (bool, error)
+	Save(ctx context.Context, key string, content []byte, ttl time.Duration) error
+	Load(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Deduplicator detects retried requests. The zero value never detects duplicates.
+type Deduplicator struct {
+	store Store
+	ttl   time.Duration
+}
+
+func New(store Store, ttl time.Duration) Deduplicator {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return Deduplicator{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Claim marks the key as being processed, until Done or Release is called. For a duplicate, it returns the cached response, nil if the first request is still in-flight.
+// Errors of the store are logged and the request is processed, a duplicate being better than a lost request.
+func (d Deduplicator) Claim(ctx context.Context, key string) ([]byte, bool) {
+	if d.store == nil || len(key) == 0 {
+		return nil, false
+	}
+
+	claimed, err := d.store.SaveIfAbsent(ctx, key, pending, d.ttl)
+	if err != nil {
+		slog.LogAttrs(ctx, slog.LevelError, "claim dedup key", slog.String("key", key), slog.Any("error", err))
+		return nil, false
+	}
+
+	if claimed {
+		return nil, false
+	}
+
+	content, err := d.store.Load(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, false
+		}
+
+		slog.LogAttrs(ctx, slog.LevelError, "load dedup response", slog.String("key", key), slog.Any("error", err))
+		return nil, false
+	}
+
+	if bytes.Equal(content, pending) {
+		return nil, true
+	}
+
+	return content, true
+}
+
+// Done caches the response of a claimed key, so that a duplicate receives it
+func (d Deduplicator) Done(ctx context.Context, key string, response any) {
+	if d.store == nil || len(key) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(response)
+	if err != nil {
+		slog.LogAttrs(ctx, slog.LevelError, "marshal dedup response", slog.String("key", key), slog.Any("error", err))
+		return
+	}
+
+	if err := d.store.Save(ctx, key, payload, d.ttl); err != nil {
+		slog.LogAttrs(ctx, slog.LevelError, "save dedup response", slog.String("key", key), slog.Any("error", err))
+	}
+}
+
+// Release forgets a claimed key whose processing failed, so that a retry is processed instead of waiting for a response that will never come
+func (d Deduplicator) Release(ctx context.Context, key string) {
+	if d.store == nil || len(key) == 0 {
+		return
+	}
+
+	if err := d.store.Delete(ctx, key); err != nil {
+		slog.LogAttrs(ctx, slog.LevelError, "release dedup key", slog.String("key", key), slog.Any("error", err))
+	}
+}
+
+// WriteDuplicate answers a duplicate with its cached response, or with an empty response if there is none yet
+func WriteDuplicate(w http.ResponseWriter, cached []byte) {
+	if len(cached) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(cached)
+}
@@ -0,0 +1,175 @@
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ViBiOh/httputils/v4/pkg/redis"
+)
+
+// claimTimeout bounds the lock held while checking and saving a key in Redis
+const claimTimeout = 5 * time.Second
+
+type memoryEntry struct {
+	expireAt time.Time
+	key      string
+	content  []byte
+}
+
+// MemoryStore is an in-process store, only suitable for a single instance. Entries are kept in saving order, so that expired ones are purged from the oldest without scanning them all.
+type MemoryStore struct {
+	entries map[string]*list.Element
+	order   *list.List
+	mutex   sync.Mutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (m *MemoryStore) SaveIfAbsent(_ context.Context, key string, content []byte, ttl time.Duration) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if element, ok := m.entries[key]; ok && time.Now().Before(element.Value.(memoryEntry).expireAt) {
+		return false, nil
+	}
+
+	m.save(key, content, ttl)
+
+	return true, nil
+}
+
+func (m *MemoryStore) Save(_ context.Context, key string, content []byte, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.save(key, content, ttl)
+
+	return nil
+}
+
+func (m *MemoryStore) Load(_ context.Context, key string) ([]byte, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	entry := element.Value.(memoryEntry)
+	if time.Now().After(entry.expireAt) {
+		return nil, ErrNotFound
+	}
+
+	return entry.content, nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.remove(element)
+	}
+
+	return nil
+}
+
+// save stores the entry as the most recent one and purges the expired oldest ones, the mutex must be held. The Deduplicator using a single TTL, saving order matches expiration order.
+func (m *MemoryStore) save(key string, content []byte, ttl time.Duration) {
+	now := time.Now()
+
+	for element := m.order.Front(); element != nil && now.After(element.Value.(memoryEntry).expireAt); element = m.order.Front() {
+		m.remove(element)
+	}
+
+	if element, ok := m.entries[key]; ok {
+		m.remove(element)
+	}
+
+	m.entries[key] = m.order.PushBack(memoryEntry{
+		key:      key,
+		content:  content,
+		expireAt: now.Add(ttl),
+	})
+}
+
+func (m *MemoryStore) remove(element *list.Element) {
+	delete(m.entries, element.Value.(memoryEntry).key)
+	m.order.Remove(element)
+}
+
+// RedisStore shares the requests received between instances, under the given prefix. Keys are claimed under an exclusive lock, so that only one instance processes a request.
+type RedisStore struct {
+	redis  redis.Client
+	prefix string
+}
+
+func NewRedisStore(redisApp redis.Client, prefix string) RedisStore {
+	return RedisStore{
+		redis:  redisApp,
+		prefix: prefix,
+	}
+}
+
+// SaveIfAbsent reports the key as already present when another instance holds the lock, as it's claiming the same request
+func (r RedisStore) SaveIfAbsent(ctx context.Context, key string, content []byte, ttl time.Duration) (bool, error) {
+	var saved bool
+
+	acquired, err := r.redis.Exclusive(ctx, r.key("lock:"+key), claimTimeout, func(ctx context.Context) error {
+		existing, err := r.redis.Load(ctx, r.key(key))
+		if err != nil {
+			return fmt.Errorf("load redis: %w", err)
+		}
+
+		if len(existing) != 0 {
+			return nil
+		}
+
+		if err := r.redis.Store(ctx, r.key(key), content, ttl); err != nil {
+			return fmt.Errorf("store redis: %w", err)
+		}
+
+		saved = true
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("exclusive: %w", err)
+	}
+
+	return acquired && saved, nil
+}
+
+func (r RedisStore) Save(ctx context.Context, key string, content []byte, ttl time.Duration) error {
+	return r.redis.Store(ctx, r.key(key), content, ttl)
+}
+
+func (r RedisStore) Load(ctx context.Context, key string) ([]byte, error) {
+	content, err := r.redis.Load(ctx, r.key(key))
+	if err != nil {
+		return nil, fmt.Errorf("load redis: %w", err)
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return content, nil
+}
+
+func (r RedisStore) Delete(ctx context.Context, key string) error {
+	return r.redis.Delete(ctx, r.key(key))
+}
+
+func (r RedisStore) key(key string) string {
+	return fmt.Sprintf("%s:%s", r.prefix, key)
+}
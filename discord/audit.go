@@ -0,0 +1,156 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+const (
+	auditLogReasonHeader = "X-Audit-Log-Reason"
+	maxAuditLogReason    = 512
+	maxAuditLogEntries   = 100
+)
+
+type auditLogReasonKey struct{}
+
+// WithAuditLogReason attaches a reason to every mutating call made with the context, displayed in the guild's audit log
+func WithAuditLogReason(ctx context.Context, reason string) context.Context {
+	if len(reason) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, auditLogReasonKey{}, truncate(reason, maxAuditLogReason))
+}
+
+func AuditLogReason(ctx context.Context) string {
+	reason, _ := ctx.Value(auditLogReasonKey{}).(string)
+	return reason
+}
+
+func withAuditLogReason(ctx context.Context, req request.Request) request.Request {
+	reason := AuditLogReason(ctx)
+	if len(reason) == 0 {
+		return req
+	}
+
+	return req.Header(auditLogReasonHeader, url.PathEscape(reason))
+}
+
+type AuditLogEvent int
+
+const (
+	GuildUpdateAudit                AuditLogEvent = 1
+	ChannelCreateAudit              AuditLogEvent = 10
+	ChannelUpdateAudit              AuditLogEvent = 11
+	ChannelDeleteAudit              AuditLogEvent = 12
+	MemberKickAudit                 AuditLogEvent = 20
+	MemberBanAddAudit               AuditLogEvent = 22
+	MemberBanRemoveAudit            AuditLogEvent = 23
+	MemberUpdateAudit               AuditLogEvent = 24
+	MemberRoleUpdateAudit           AuditLogEvent = 25
+	RoleCreateAudit                 AuditLogEvent = 30
+	RoleUpdateAudit                 AuditLogEvent = 31
+	RoleDeleteAudit                 AuditLogEvent = 32
+	MessageDeleteAudit              AuditLogEvent = 72
+	MessageBulkDeleteAudit          AuditLogEvent = 73
+	MessagePinAudit                 AuditLogEvent = 74
+	MessageUnpinAudit               AuditLogEvent = 75
+	ScheduledEventCreateAudit       AuditLogEvent = 100
+	ScheduledEventUpdateAudit       AuditLogEvent = 101
+	ScheduledEventDeleteAudit       AuditLogEvent = 102
+	ThreadCreateAudit               AuditLogEvent = 110
+	ThreadUpdateAudit               AuditLogEvent = 111
+	ThreadDeleteAudit               AuditLogEvent = 112
+	AutoModerationRuleCreateAudit   AuditLogEvent = 140
+	AutoModerationRuleUpdateAudit   AuditLogEvent = 141
+	AutoModerationRuleDeleteAudit   AuditLogEvent = 142
+	AutoModerationBlockMessageAudit AuditLogEvent = 143
+)
+
+type AuditLogChange struct {
+	Key      string          `json:"key"`
+	NewValue json.RawMessage `json:"new_value,omitempty"`
+	OldValue json.RawMessage `json:"old_value,omitempty"`
+}
+
+type AuditLogOptions struct {
+	ChannelID string `json:"channel_id,omitempty"`
+	Count     string `json:"count,omitempty"`
+	MessageID string `json:"message_id,omitempty"`
+}
+
+type AuditLogEntry struct {
+	Options    *AuditLogOptions `json:"options,omitempty"`
+	ID         string           `json:"id"`
+	TargetID   string           `json:"target_id"`
+	UserID     string           `json:"user_id"`
+	Reason     string           `json:"reason"`
+	Changes    []AuditLogChange `json:"changes"`
+	ActionType AuditLogEvent    `json:"action_type"`
+}
+
+type AuditLog struct {
+	Entries []AuditLogEntry `json:"audit_log_entries"`
+	Users   []User          `json:"users"`
+}
+
+// User returns the user who made the change, if provided by the audit log
+func (a AuditLog) User(entry AuditLogEntry) (User, bool) {
+	for _, user := range a.Users {
+		if user.ID == entry.UserID {
+			return user, true
+		}
+	}
+
+	return User{}, false
+}
+
+type AuditLogFilter struct {
+	UserID     string
+	Before     string
+	ActionType AuditLogEvent
+	Limit      int
+}
+
+func (f AuditLogFilter) query() url.Values {
+	query := url.Values{}
+
+	if len(f.UserID) != 0 {
+		query.Set("user_id", f.UserID)
+	}
+
+	if len(f.Before) != 0 {
+		query.Set("before", f.Before)
+	}
+
+	if f.ActionType != 0 {
+		query.Set("action_type", strconv.Itoa(int(f.ActionType)))
+	}
+
+	if f.Limit > 0 {
+		query.Set("limit", strconv.Itoa(min(f.Limit, maxAuditLogEntries)))
+	}
+
+	return query
+}
+
+// AuditLog reads the most recent entries of the guild audit log matching the filter, use the last entry's ID as `Before` to read further
+func (s Service) AuditLog(ctx context.Context, req request.Request, guildID string, filter AuditLogFilter) (AuditLog, error) {
+	path := fmt.Sprintf("/guilds/%s/audit-logs", guildID)
+	if query := filter.query(); len(query) != 0 {
+		path += "?" + query.Encode()
+	}
+
+	output, err := read[AuditLog](ctx, req.Path(path).Method(http.MethodGet), nil)
+	if err != nil {
+		return output, fmt.Errorf("audit log: %w", err)
+	}
+
+	return output, nil
+}
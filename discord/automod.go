@@ -0,0 +1,355 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+type AutoModerationEventType int
+
+const (
+	MessageSendModeration  AutoModerationEventType = 1
+	MemberUpdateModeration AutoModerationEventType = 2
+)
+
+type AutoModerationTriggerType int
+
+const (
+	KeywordTrigger       AutoModerationTriggerType = 1
+	SpamTrigger          AutoModerationTriggerType = 3
+	KeywordPresetTrigger AutoModerationTriggerType = 4
+	MentionSpamTrigger   AutoModerationTriggerType = 5
+	MemberProfileTrigger AutoModerationTriggerType = 6
+)
+
+type AutoModerationActionType int
+
+const (
+	BlockMessageAction           AutoModerationActionType = 1
+	SendAlertMessageAction       AutoModerationActionType = 2
+	TimeoutAction                AutoModerationActionType = 3
+	BlockMemberInteractionAction AutoModerationActionType = 4
+)
+
+type AutoModerationTriggerMetadata struct {
+	KeywordFilter                []string `json:"keyword_filter,omitempty"`
+	RegexPatterns                []string `json:"regex_patterns,omitempty"`
+	Presets                      []int    `json:"presets,omitempty"`
+	AllowList                    []string `json:"allow_list,omitempty"`
+	MentionTotalLimit            int      `json:"mention_total_limit,omitempty"`
+	MentionRaidProtectionEnabled bool     `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+type AutoModerationActionMetadata struct {
+	ChannelID       string `json:"channel_id,omitempty"`
+	CustomMessage   string `json:"custom_message,omitempty"`
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+}
+
+type AutoModerationAction struct {
+	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
+	Type     AutoModerationActionType      `json:"type"`
+}
+
+type AutoModerationRule struct {
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	ID              string                         `json:"id,omitempty"`
+	GuildID         string                         `json:"guild_id,omitempty"`
+	CreatorID       string                         `json:"creator_id,omitempty"`
+	Name            string                         `json:"name"`
+	Actions         []AutoModerationAction         `json:"actions"`
+	ExemptRoles     []string                       `json:"exempt_roles,omitempty"`
+	ExemptChannels  []string                       `json:"exempt_channels,omitempty"`
+	EventType       AutoModerationEventType        `json:"event_type"`
+	TriggerType     AutoModerationTriggerType      `json:"trigger_type,omitempty"`
+	Enabled         bool                           `json:"enabled"`
+}
+
+// autoModerationRulePayload holds the writable fields of a rule
+type autoModerationRulePayload struct {
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Name            string                         `json:"name"`
+	Actions         []AutoModerationAction         `json:"actions"`
+	ExemptRoles     []string                       `json:"exempt_roles"`
+	ExemptChannels  []string                       `json:"exempt_channels"`
+	EventType       AutoModerationEventType        `json:"event_type"`
+	TriggerType     AutoModerationTriggerType      `json:"trigger_type,omitempty"`
+	Enabled         bool                           `json:"enabled"`
+}
+
+// payload keeps the writable fields, empty lists being sent as such to clear them on update
+func (r AutoModerationRule) payload() autoModerationRulePayload {
+	return autoModerationRulePayload{
+		TriggerMetadata: r.TriggerMetadata,
+		Name:            r.Name,
+		Actions:         orEmpty(r.Actions),
+		ExemptRoles:     orEmpty(r.ExemptRoles),
+		ExemptChannels:  orEmpty(r.ExemptChannels),
+		EventType:       r.EventType,
+		TriggerType:     r.TriggerType,
+		Enabled:         r.Enabled,
+	}
+}
+
+func orEmpty[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+
+	return values
+}
+
+func (s Service) AutoModerationRules(ctx context.Context, req request.Request, guildID string) ([]AutoModerationRule, error) {
+	output, err := read[[]AutoModerationRule](ctx, req.Path("/guilds/%s/auto-moderation/rules", guildID).Method(http.MethodGet), nil)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return output, nil
+}
+
+func (s Service) CreateAutoModerationRule(ctx context.Context, req request.Request, guildID string, rule AutoModerationRule) (AutoModerationRule, error) {
+	output, err := read[AutoModerationRule](ctx, req.Path("/guilds/%s/auto-moderation/rules", guildID).Method(http.MethodPost), rule.payload())
+	if err != nil {
+		return output, fmt.Errorf("create: %w", err)
+	}
+
+	return output, nil
+}
+
+// UpdateAutoModerationRule updates the rule, its trigger type can't be changed
+func (s Service) UpdateAutoModerationRule(ctx context.Context, req request.Request, rule AutoModerationRule) (AutoModerationRule, error) {
+	payload := rule.payload()
+	payload.TriggerType = 0
+
+	output, err := read[AutoModerationRule](ctx, req.Path("/guilds/%s/auto-moderation/rules/%s", rule.GuildID, rule.ID).Method(http.MethodPatch), payload)
+	if err != nil {
+		return output, fmt.Errorf("update: %w", err)
+	}
+
+	return output, nil
+}
+
+func (s Service) DeleteAutoModerationRule(ctx context.Context, req request.Request, rule AutoModerationRule) error {
+	if err := discard(ctx, req.Path("/guilds/%s/auto-moderation/rules/%s", rule.GuildID, rule.ID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+type RuleChangeType string
+
+const (
+	CreateRule RuleChangeType = "+"
+	UpdateRule RuleChangeType = "~"
+	DeleteRule RuleChangeType = "-"
+)
+
+type RuleChange struct {
+	GuildID string
+	Name    string
+	Type    RuleChangeType
+	Fields  []string
+}
+
+func (c RuleChange) String() string {
+	if len(c.Fields) == 0 {
+		return fmt.Sprintf("%s guild `%s`: rule `%s`", c.Type, c.GuildID, c.Name)
+	}
+
+	return fmt.Sprintf("%s guild `%s`: rule `%s` (%s)", c.Type, c.GuildID, c.Name, strings.Join(c.Fields, ", "))
+}
+
+// SyncAutoModerationRules reconciles the rules, keyed by name, against the guilds, or every guild of the bot if none is given.
+// Rules created by the bot that are not in the set are deleted, rules created by moderators are left untouched.
+// With dryRun, the changes are computed but not applied.
+func (s Service) SyncAutoModerationRules(ctx context.Context, req request.Request, rules map[string]AutoModerationRule, dryRun bool, guildIDs ...string) ([]RuleChange, error) {
+	bot, err := CurrentUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("current user: %w", err)
+	}
+
+	if len(guildIDs) == 0 {
+		guilds, err := Guilds(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("guilds: %w", err)
+		}
+
+		for _, guild := range guilds {
+			guildIDs = append(guildIDs, guild.ID)
+		}
+	}
+
+	var changes []RuleChange
+
+	for _, guildID := range guildIDs {
+		existing, err := s.AutoModerationRules(ctx, req, guildID)
+		if err != nil {
+			return changes, fmt.Errorf("rules of guild `%s`: %w", guildID, err)
+		}
+
+		guildChanges, err := s.syncGuildRules(ctx, req, guildID, bot.ID, rules, existing, dryRun)
+		changes = append(changes, guildChanges...)
+
+		if err != nil {
+			return changes, fmt.Errorf("sync guild `%s`: %w", guildID, err)
+		}
+	}
+
+	return changes, nil
+}
+
+func (s Service) syncGuildRules(ctx context.Context, req request.Request, guildID, botID string, rules map[string]AutoModerationRule, existing []AutoModerationRule, dryRun bool) ([]RuleChange, error) {
+	current := make(map[string]AutoModerationRule, len(existing))
+	for _, rule := range existing {
+		current[rule.Name] = rule
+	}
+
+	var changes []RuleChange
+
+	apply := func(change RuleChange, action func() error) error {
+		changes = append(changes, change)
+
+		if dryRun {
+			return nil
+		}
+
+		return action()
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(rules)) {
+		rule := rules[name]
+		rule.Name = name
+		rule.GuildID = guildID
+
+		previous, ok := current[name]
+		delete(current, name)
+
+		switch {
+		case !ok:
+			err := apply(RuleChange{GuildID: guildID, Name: name, Type: CreateRule}, func() error {
+				_, err := s.CreateAutoModerationRule(ctx, req, guildID, rule)
+				return err
+			})
+			if err != nil {
+				return changes, fmt.Errorf("create `%s`: %w", name, err)
+			}
+
+		case previous.TriggerType != rule.TriggerType:
+			err := apply(RuleChange{GuildID: guildID, Name: name, Type: UpdateRule, Fields: []string{"trigger_type"}}, func() error {
+				if err := s.DeleteAutoModerationRule(ctx, req, previous); err != nil {
+					return err
+				}
+
+				_, err := s.CreateAutoModerationRule(ctx, req, guildID, rule)
+				return err
+			})
+			if err != nil {
+				return changes, fmt.Errorf("recreate `%s`: %w", name, err)
+			}
+
+		default:
+			fields := rule.diff(previous)
+			if len(fields) == 0 {
+				continue
+			}
+
+			rule.ID = previous.ID
+
+			err := apply(RuleChange{GuildID: guildID, Name: name, Type: UpdateRule, Fields: fields}, func() error {
+				_, err := s.UpdateAutoModerationRule(ctx, req, rule)
+				return err
+			})
+			if err != nil {
+				return changes, fmt.Errorf("update `%s`: %w", name, err)
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		rule := current[name]
+		if rule.CreatorID != botID {
+			continue
+		}
+
+		if err := apply(RuleChange{GuildID: guildID, Name: name, Type: DeleteRule}, func() error {
+			return s.DeleteAutoModerationRule(ctx, req, rule)
+		}); err != nil {
+			return changes, fmt.Errorf("delete `%s`: %w", name, err)
+		}
+	}
+
+	return changes, nil
+}
+
+func (r AutoModerationRule) diff(other AutoModerationRule) []string {
+	var fields []string
+
+	r, other = r.normalized(), other.normalized()
+
+	compare := func(name string, value, otherValue any) {
+		if !sameJSON(value, otherValue) {
+			fields = append(fields, name)
+		}
+	}
+
+	compare("event_type", r.EventType, other.EventType)
+	compare("trigger_metadata", r.TriggerMetadata, other.TriggerMetadata)
+	compare("actions", r.Actions, other.Actions)
+	compare("exempt_roles", sortedOrEmpty(r.ExemptRoles), sortedOrEmpty(other.ExemptRoles))
+	compare("exempt_channels", sortedOrEmpty(r.ExemptChannels), sortedOrEmpty(other.ExemptChannels))
+	compare("enabled", r.Enabled, other.Enabled)
+
+	return fields
+}
+
+// normalized fills empty metadata the way Discord returns it, so that omitted and empty values are considered equal
+func (r AutoModerationRule) normalized() AutoModerationRule {
+	if r.TriggerMetadata == nil {
+		r.TriggerMetadata = &AutoModerationTriggerMetadata{}
+	}
+
+	actions := make([]AutoModerationAction, len(r.Actions))
+	for i, action := range r.Actions {
+		if action.Metadata == nil {
+			action.Metadata = &AutoModerationActionMetadata{}
+		}
+
+		actions[i] = action
+	}
+
+	r.Actions = actions
+
+	return r
+}
+
+func sameJSON(value, other any) bool {
+	valuePayload, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	otherPayload, err := json.Marshal(other)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(valuePayload, otherPayload)
+}
+
+func sortedOrEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return slices.Sorted(slices.Values(values))
+}
@@ -27,15 +27,20 @@ func (s Service) ConfigureCommands(ctx context.Context, commands map[string]Comm
 	rootURL := fmt.Sprintf("/applications/%s", s.applicationID)
 
 	for name, command := range commands {
+		if len(command.Name) == 0 {
+			command.Name = name
+		}
+
+		if command.Type == UserCommand || command.Type == MessageCommand {
+			if len(command.Description) != 0 || len(command.Options) != 0 {
+				return fmt.Errorf("context menu command `%s` cannot have description or options", name)
+			}
+		}
+
 		for _, registerURL := range getRegisterURLs(command) {
 			absoluteURL := rootURL + registerURL
 
-		configure:
-			if resp, err := req.Method(http.MethodPost).Path(absoluteURL).StreamJSON(ctx, command); err != nil {
-				if IsRetryable(ctx, resp) {
-					goto configure
-				}
-
+			if err := discard(ctx, req.Method(http.MethodPost).Path(absoluteURL), command); err != nil {
 				return fmt.Errorf("configure `%s` command for url `%s`: %w", name, registerURL, err)
 			}
 		}
@@ -72,7 +77,7 @@ func (s Service) SigninClient(ctx context.Context, scopes ...string) (request.Re
 }
 
 func IsRetryable(ctx context.Context, resp *http.Response) bool {
-	if resp.StatusCode != http.StatusTooManyRequests {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
 		return false
 	}
 
@@ -84,6 +89,63 @@ func IsRetryable(ctx context.Context, resp *http.Response) bool {
 	return false
 }
 
+func call(ctx context.Context, req request.Request, payload any) (*http.Response, error) {
+	return sendPayload(ctx, req, payload, InteractionDataResponse{})
+}
+
+// sendPayload sends the payload as JSON, or as multipart with the attachments of data, retrying when rate limited
+func sendPayload(ctx context.Context, req request.Request, payload any, data InteractionDataResponse) (*http.Response, error) {
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	req = withAuditLogReason(ctx, req)
+
+retry:
+	switch {
+	case len(data.Attachments) > 0:
+		resp, err = req.Multipart(ctx, writeMultipartPayload(payload, data))
+	case payload == nil:
+		resp, err = req.Send(ctx, nil)
+	default:
+		resp, err = req.StreamJSON(ctx, payload)
+	}
+
+	if err != nil {
+		if IsRetryable(ctx, resp) {
+			goto retry
+		}
+
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func discard(ctx context.Context, req request.Request, payload any) error {
+	resp, err := call(ctx, req, payload)
+	if err != nil {
+		return err
+	}
+
+	if err := request.DiscardBody(resp.Body); err != nil {
+		return fmt.Errorf("discard: %w", err)
+	}
+
+	return nil
+}
+
+func read[T any](ctx context.Context, req request.Request, payload any) (T, error) {
+	resp, err := call(ctx, req, payload)
+	if err != nil {
+		var output T
+		return output, err
+	}
+
+	return httpjson.Read[T](resp)
+}
+
 func getRegisterURLs(command Command) []string {
 	if len(command.Guilds) == 0 {
 		return []string{"/commands"}
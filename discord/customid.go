@@ -0,0 +1,224 @@
+package discord
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	DefaultCustomIDTTL = time.Hour
+
+	inlineCustomIDPrefix = "!"
+	storedCustomIDPrefix = "#"
+	signatureSize        = 12
+
+	pairSeparator  = "|"
+	valueSeparator = "="
+)
+
+var compactEscaper = strings.NewReplacer("%", "%25", pairSeparator, "%7C", valueSeparator, "%3D")
+
+var (
+	ErrCustomIDExpired   = errors.New("custom_id has expired")
+	ErrCustomIDSignature = errors.New("custom_id has an invalid signature")
+)
+
+// CustomIDCodec encodes values inside a signed `custom_id`, and falls back to a store when they don't fit in
+type CustomIDCodec struct {
+	store  CustomIDStore
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewCustomIDCodec(secret string, store CustomIDStore, ttl time.Duration) (CustomIDCodec, error) {
+	if len(secret) == 0 {
+		return CustomIDCodec{}, errors.New("secret is required")
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultCustomIDTTL
+	}
+
+	return CustomIDCodec{
+		secret: []byte(secret),
+		store:  store,
+		ttl:    ttl,
+	}, nil
+}
+
+// Encode signs the values inline when they fit in the 100 characters of a `custom_id`, or saves them in the store
+func (c CustomIDCodec) Encode(ctx context.Context, values url.Values) (string, error) {
+	inline := encodeCompact(values)
+
+	if customID := inlineCustomIDPrefix + c.sign(inline) + inline; utf8.RuneCountInString(customID) <= customIDMaxLen {
+		return customID, nil
+	}
+
+	content := values.Encode()
+
+	if c.store == nil {
+		return "", fmt.Errorf("content of %d characters doesn't fit in custom_id and no store is configured", len(content))
+	}
+
+	key, err := storeCustomID(ctx, c.store, content, c.ttl)
+	if err != nil {
+		return "", fmt.Errorf("store: %w", err)
+	}
+
+	return storedCustomIDPrefix + key, nil
+}
+
+func (c CustomIDCodec) Decode(ctx context.Context, customID string, statics ...string) (url.Values, error) {
+	if slices.Contains(statics, customID) {
+		return url.ParseQuery(customID)
+	}
+
+	if inline, ok := strings.CutPrefix(customID, inlineCustomIDPrefix); ok {
+		signatureLen := base64.RawURLEncoding.EncodedLen(signatureSize)
+		if len(inline) < signatureLen {
+			return nil, ErrCustomIDSignature
+		}
+
+		signature, content := inline[:signatureLen], inline[signatureLen:]
+		if !hmac.Equal([]byte(signature), []byte(c.sign(content))) {
+			return nil, ErrCustomIDSignature
+		}
+
+		return decodeCompact(content)
+	}
+
+	if c.store == nil {
+		return nil, errors.New("no store is configured")
+	}
+
+	return restoreCustomID(ctx, c.store, strings.TrimPrefix(customID, storedCustomIDPrefix), nil)
+}
+
+// Invalidate removes the stored content of given custom IDs. Only the ones created by Encode are considered, inline ones can't be revoked.
+func (c CustomIDCodec) Invalidate(ctx context.Context, customIDs ...string) error {
+	if c.store == nil {
+		return nil
+	}
+
+	var keys []string
+
+	for _, customID := range customIDs {
+		if key, ok := strings.CutPrefix(customID, storedCustomIDPrefix); ok && len(key) != 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.store.Delete(ctx, keys...)
+}
+
+func (c CustomIDCodec) sign(content string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(content))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureSize])
+}
+
+// encodeCompact writes values as `key=value` pairs separated by `|`, only escaping separators so that most values are kept raw
+func encodeCompact(values url.Values) string {
+	var builder strings.Builder
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		for _, value := range values[key] {
+			if builder.Len() != 0 {
+				builder.WriteString(pairSeparator)
+			}
+
+			builder.WriteString(compactEscaper.Replace(key))
+			builder.WriteString(valueSeparator)
+			builder.WriteString(compactEscaper.Replace(value))
+		}
+	}
+
+	return builder.String()
+}
+
+func decodeCompact(content string) (url.Values, error) {
+	values := url.Values{}
+
+	if len(content) == 0 {
+		return values, nil
+	}
+
+	for pair := range strings.SplitSeq(content, pairSeparator) {
+		rawKey, rawValue, _ := strings.Cut(pair, valueSeparator)
+
+		key, err := url.PathUnescape(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("unescape key: %w", err)
+		}
+
+		value, err := url.PathUnescape(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("unescape value: %w", err)
+		}
+
+		values.Add(key, value)
+	}
+
+	return values, nil
+}
+
+// CustomIDs returns all the custom IDs found in the given components, recursively
+func CustomIDs(components []Component) []string {
+	var output []string
+
+	for _, component := range components {
+		if len(component.CustomID) != 0 {
+			output = append(output, component.CustomID)
+		}
+
+		output = append(output, CustomIDs(component.Components)...)
+
+		if component.Accessory != nil {
+			output = append(output, CustomIDs([]Component{*component.Accessory})...)
+		}
+	}
+
+	return output
+}
+
+// WithCustomIDCodec sets the codec used to invalidate stored custom IDs of messages being replaced or deleted
+func (s Service) WithCustomIDCodec(codec CustomIDCodec) Service {
+	s.customIDs = codec
+	return s
+}
+
+func (s Service) invalidateCustomIDs(ctx context.Context, previous, next []Component) {
+	if s.customIDs.store == nil {
+		return
+	}
+
+	kept := CustomIDs(next)
+
+	var stale []string
+
+	for _, customID := range CustomIDs(previous) {
+		if !slices.Contains(kept, customID) {
+			stale = append(stale, customID)
+		}
+	}
+
+	if err := s.customIDs.Invalidate(ctx, stale...); err != nil {
+		slog.LogAttrs(ctx, slog.LevelError, "invalidate custom_id", slog.Any("error", err))
+	}
+}
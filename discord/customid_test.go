@@ -0,0 +1,193 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func newTestCodec(t *testing.T, store CustomIDStore) CustomIDCodec {
+	t.Helper()
+
+	codec, err := NewCustomIDCodec("secret", store, 0)
+	if err != nil {
+		t.Fatalf("codec: %s", err)
+	}
+
+	return codec
+}
+
+func TestCustomIDCodecRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		input      url.Values
+		wantPrefix string
+	}{
+		"empty": {
+			input:      url.Values{},
+			wantPrefix: inlineCustomIDPrefix,
+		},
+		"signed": {
+			input:      url.Values{"action": {"vote"}, "id": {"42"}},
+			wantPrefix: inlineCustomIDPrefix,
+		},
+		"separators": {
+			input:      url.Values{"a|b": {"c=d"}, "e=f": {"g|h", "100%", "%7C"}, "%": {""}},
+			wantPrefix: inlineCustomIDPrefix,
+		},
+		"stored": {
+			input:      url.Values{"content": {strings.Repeat("long value ", 20)}},
+			wantPrefix: storedCustomIDPrefix,
+		},
+		"unicode": {
+			input:      url.Values{"emoji": {strings.Repeat("🎉", 70)}},
+			wantPrefix: inlineCustomIDPrefix,
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			t.Parallel()
+
+			codec := newTestCodec(t, NewMemoryCustomIDStore(0, 0))
+
+			customID, err := codec.Encode(context.Background(), testCase.input)
+			if err != nil {
+				t.Fatalf("encode: %s", err)
+			}
+
+			if !strings.HasPrefix(customID, testCase.wantPrefix) {
+				t.Errorf("Encode() = `%s`, want prefix `%s`", customID, testCase.wantPrefix)
+			}
+
+			if length := utf8.RuneCountInString(customID); length > customIDMaxLen {
+				t.Errorf("Encode() has %d characters, want at most %d", length, customIDMaxLen)
+			}
+
+			output, err := codec.Decode(context.Background(), customID)
+			if err != nil {
+				t.Fatalf("decode: %s", err)
+			}
+
+			if !reflect.DeepEqual(output, testCase.input) {
+				t.Errorf("Decode() = %#v, want %#v", output, testCase.input)
+			}
+		})
+	}
+}
+
+func TestCustomIDCodecDecode(t *testing.T) {
+	t.Parallel()
+
+	codec := newTestCodec(t, NewMemoryCustomIDStore(0, 0))
+
+	customID, err := codec.Encode(context.Background(), url.Values{"action": {"vote"}})
+	if err != nil {
+		t.Fatalf("encode: %s", err)
+	}
+
+	other := newTestCodec(t, nil)
+	other.secret = []byte("other")
+
+	signatureStart := len(inlineCustomIDPrefix)
+
+	cases := map[string]struct {
+		codec    CustomIDCodec
+		customID string
+		wantErr  error
+	}{
+		"flipped signature": {
+			codec:    codec,
+			customID: customID[:signatureStart] + flip(customID[signatureStart]) + customID[signatureStart+1:],
+			wantErr:  ErrCustomIDSignature,
+		},
+		"flipped payload": {
+			codec:    codec,
+			customID: customID[:len(customID)-1] + flip(customID[len(customID)-1]),
+			wantErr:  ErrCustomIDSignature,
+		},
+		"truncated": {
+			codec:    codec,
+			customID: inlineCustomIDPrefix + "abc",
+			wantErr:  ErrCustomIDSignature,
+		},
+		"other secret": {
+			codec:    other,
+			customID: customID,
+			wantErr:  ErrCustomIDSignature,
+		},
+		"unknown key": {
+			codec:    codec,
+			customID: storedCustomIDPrefix + "unknown",
+			wantErr:  ErrCustomIDExpired,
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := testCase.codec.Decode(context.Background(), testCase.customID); !errors.Is(err, testCase.wantErr) {
+				t.Errorf("Decode() = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestCustomIDCodecInvalidate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	codec := newTestCodec(t, NewMemoryCustomIDStore(0, 0))
+
+	long := url.Values{"content": {strings.Repeat("long value ", 20)}}
+
+	invalidated, err := codec.Encode(ctx, long)
+	if err != nil {
+		t.Fatalf("encode: %s", err)
+	}
+
+	kept, err := codec.Encode(ctx, long)
+	if err != nil {
+		t.Fatalf("encode: %s", err)
+	}
+
+	if invalidated == kept {
+		t.Fatal("Encode() reused the stored key")
+	}
+
+	if err := codec.Invalidate(ctx, invalidated); err != nil {
+		t.Fatalf("invalidate: %s", err)
+	}
+
+	if _, err := codec.Decode(ctx, invalidated); !errors.Is(err, ErrCustomIDExpired) {
+		t.Errorf("Decode() = %v, want %v", err, ErrCustomIDExpired)
+	}
+
+	if _, err := codec.Decode(ctx, kept); err != nil {
+		t.Errorf("Decode() = %v, want nil", err)
+	}
+}
+
+func TestCustomIDCodecWithoutStore(t *testing.T) {
+	t.Parallel()
+
+	codec := newTestCodec(t, nil)
+
+	if _, err := codec.Encode(context.Background(), url.Values{"content": {strings.Repeat("long value ", 20)}}); err == nil {
+		t.Error("Encode() = nil, want an error")
+	}
+}
+
+func flip(char byte) string {
+	if char == 'A' {
+		return "B"
+	}
+
+	return "A"
+}
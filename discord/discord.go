@@ -16,6 +16,7 @@ import (
 	"net/textproto"
 	"os"
 
+	"github.com/ViBiOh/ChatPotte/dedup"
 	"github.com/ViBiOh/flags"
 	"github.com/ViBiOh/httputils/v4/pkg/httperror"
 	"github.com/ViBiOh/httputils/v4/pkg/httpjson"
@@ -29,14 +30,20 @@ type OnMessage func(context.Context, InteractionRequest) (InteractionResponse, b
 var discordRequest = request.New().URL("https://discord.com/api/v10")
 
 type Service struct {
-	tracer        trace.Tracer
-	handler       OnMessage
-	applicationID string
-	clientID      string
-	clientSecret  string
-	botToken      string
-	website       string
-	publicKey     []byte
+	tracer          trace.Tracer
+	install         *Invite
+	handler         OnMessage
+	roleConnector   RoleConnector
+	customIDs       CustomIDCodec
+	dedup           dedup.Deduplicator
+	applicationID   string
+	clientID        string
+	clientSecret    string
+	botToken        string
+	website         string
+	roleCallbackURL string
+	publicKey       []byte
+	truncate        bool
 }
 
 type Config struct {
@@ -92,11 +99,26 @@ func (s Service) NewServeMux() *http.ServeMux {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("GET /oauth", s.handleOauth)
+
+	if s.install != nil {
+		mux.HandleFunc("GET /install", s.handleInstall)
+	}
+
+	if s.roleConnector != nil {
+		mux.HandleFunc("GET /verify", s.handleVerify)
+	}
+
 	mux.HandleFunc("POST /", s.handleWebhook)
 
 	return mux
 }
 
+// WithDeduplication answers retried interactions with the response of the first one, without calling the handler again
+func (s Service) WithDeduplication(deduplicator dedup.Deduplicator) Service {
+	s.dedup = deduplicator
+	return s
+}
+
 func (s Service) checkSignature(r *http.Request) bool {
 	sig, err := hex.DecodeString(r.Header.Get("X-Signature-Ed25519"))
 	if err != nil {
@@ -149,9 +171,30 @@ func (s Service) handleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if cached, duplicate := s.dedup.Claim(ctx, message.ID); duplicate {
+		slog.LogAttrs(ctx, slog.LevelWarn, "duplicate interaction", slog.String("id", message.ID))
+		dedup.WriteDuplicate(w, cached)
+		return
+	}
+
+	var done bool
+	defer func() {
+		if !done {
+			s.dedup.Release(ctx, message.ID)
+		}
+	}()
+
 	response, delete, asyncFn := s.handler(ctx, message)
+	response.Data = s.prepare(ctx, response.Data)
 	httpjson.Write(ctx, w, http.StatusOK, response)
 
+	s.dedup.Done(ctx, message.ID, response)
+	done = true
+
+	if response.Type == UpdateMessageCallback {
+		go s.invalidateCustomIDs(context.WithoutCancel(ctx), message.Message.Components, response.Data.Components)
+	}
+
 	if delete {
 		go s.deleteMessage(context.WithoutCancel(ctx), message)
 	}
@@ -167,6 +210,7 @@ func (s Service) handleWebhook(w http.ResponseWriter, r *http.Request) {
 		defer end(&err)
 
 		deferredResponse := asyncFn(ctx)
+		deferredResponse.Data = s.prepare(ctx, deferredResponse.Data)
 
 		method, url := http.MethodPost, fmt.Sprintf("/webhooks/%s/%s", s.applicationID, message.Token)
 		if !delete {
@@ -179,6 +223,10 @@ func (s Service) handleWebhook(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if response.Type == DeferredUpdateMessage {
+			s.invalidateCustomIDs(ctx, message.Message.Components, deferredResponse.Data.Components)
+		}
+
 		if err = request.DiscardBody(resp.Body); err != nil {
 			slog.LogAttrs(ctx, slog.LevelError, "discard async body", slog.Any("error", err))
 		}
@@ -206,16 +254,10 @@ func (s Service) send(ctx context.Context, method, path string, data Interaction
 	ctx, end := telemetry.StartSpan(ctx, s.tracer, "send")
 	defer end(&err)
 
-	req := discordRequest.Method(method).Path(path)
-
-	if len(data.Attachments) > 0 {
-		return req.Multipart(ctx, writeMultipart(data))
-	}
-
-	return req.StreamJSON(ctx, data)
+	return sendPayload(ctx, discordRequest.Method(method).Path(path), data, data)
 }
 
-func writeMultipart(data InteractionDataResponse) func(*multipart.Writer) error {
+func writeMultipartPayload(payload any, data InteractionDataResponse) func(*multipart.Writer) error {
 	return func(mw *multipart.Writer) error {
 		header := textproto.MIMEHeader{}
 		header.Set("Content-Disposition", `form-data; name="payload_json"`)
@@ -225,7 +267,7 @@ func writeMultipart(data InteractionDataResponse) func(*multipart.Writer) error
 			return fmt.Errorf("create payload part: %w", err)
 		}
 
-		if err = json.NewEncoder(partWriter).Encode(data); err != nil {
+		if err = json.NewEncoder(partWriter).Encode(payload); err != nil {
 			return fmt.Errorf("encode payload part: %w", err)
 		}
 
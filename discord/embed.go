@@ -0,0 +1,211 @@
+package discord
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ColorBlurple = 0x5865F2
+	ColorGreen   = 0x57F287
+	ColorYellow  = 0xFEE75C
+	ColorFuchsia = 0xEB459E
+	ColorRed     = 0xED4245
+	ColorWhite   = 0xFFFFFF
+	ColorBlack   = 0x23272A
+)
+
+// pageFooterReserve is the length kept for the ` • Page x/y` footer suffix added by Paginate
+const pageFooterReserve = 16
+
+// RGB computes the color value of the given components
+func RGB(red, green, blue uint8) int {
+	return int(red)<<16 | int(green)<<8 | int(blue)
+}
+
+// ParseColor parses an hexadecimal color, with or without leading `#`
+func ParseColor(value string) (int, error) {
+	color, err := strconv.ParseUint(strings.TrimPrefix(value, "#"), 16, 24)
+	if err != nil {
+		return 0, fmt.Errorf("parse color `%s`: %w", value, err)
+	}
+
+	return int(color), nil
+}
+
+func NewEmbed(title, description string) Embed {
+	return Embed{
+		Title:       title,
+		Description: description,
+	}
+}
+
+func (e Embed) SetURL(url string) Embed {
+	e.URL = url
+	return e
+}
+
+func (e Embed) SetAuthor(name, url, iconURL string) Embed {
+	e.Author = &Author{
+		Name:    name,
+		URL:     url,
+		IconURL: iconURL,
+	}
+
+	return e
+}
+
+func (e Embed) SetFooter(text, iconURL string) Embed {
+	e.Footer = &Footer{
+		Text:    text,
+		IconURL: iconURL,
+	}
+
+	return e
+}
+
+func (e Embed) SetTimestamp(timestamp time.Time) Embed {
+	e.Timestamp = &timestamp
+	return e
+}
+
+func (e Embed) SetImage(url string) Embed {
+	e.Image = NewImage(url)
+	return e
+}
+
+func (e Embed) SetThumbnail(url string) Embed {
+	e.Thumbnail = NewImage(url)
+	return e
+}
+
+func (e Embed) AddField(field Field) Embed {
+	e.Fields = append(e.Fields, field)
+	return e
+}
+
+// AddInlineField adds a field displayed next to the other inline ones
+func (e Embed) AddInlineField(name, value string) Embed {
+	return e.AddField(NewField(name, value))
+}
+
+// AddBlockField adds a field displayed on its own line
+func (e Embed) AddBlockField(name, value string) Embed {
+	return e.AddField(NewBlockField(name, value))
+}
+
+func NewBlockField(name, value string) Field {
+	return Field{
+		Name:  name,
+		Value: value,
+	}
+}
+
+// Paginate splits the embed in pages of at most `fieldsPerPage` fields, each page fitting in the total length of an embed.
+// The description is split on lines when too long. Pages are numbered in the footer.
+func (e Embed) Paginate(fieldsPerPage int) []Embed {
+	if fieldsPerPage <= 0 || fieldsPerPage > maxEmbedFields {
+		fieldsPerPage = maxEmbedFields
+	}
+
+	fixed := utf8.RuneCountInString(e.Title) + pageFooterReserve
+	if e.Author != nil {
+		fixed += utf8.RuneCountInString(e.Author.Name)
+	}
+
+	if e.Footer != nil {
+		fixed += utf8.RuneCountInString(e.Footer.Text)
+	}
+
+	budget := max(maxEmbedsTotalLength-fixed, 1)
+
+	type pageContent struct {
+		description string
+		fields      []Field
+		length      int
+	}
+
+	var contents []pageContent
+
+	for _, description := range splitLines(e.Description, min(maxEmbedDescription, budget)) {
+		contents = append(contents, pageContent{description: description, length: utf8.RuneCountInString(description)})
+	}
+
+	for _, field := range e.Fields {
+		length := utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+
+		if last := len(contents) - 1; last < 0 || len(contents[last].fields) == fieldsPerPage || contents[last].length+length > budget {
+			contents = append(contents, pageContent{})
+		}
+
+		last := &contents[len(contents)-1]
+		last.fields = append(last.fields, field)
+		last.length += length
+	}
+
+	count := len(contents)
+	if count <= 1 {
+		return []Embed{e}
+	}
+
+	pages := make([]Embed, count)
+
+	for i, content := range contents {
+		page := e
+		page.Description = content.description
+		page.Fields = content.fields
+
+		footer := Footer{Text: fmt.Sprintf("Page %d/%d", i+1, count)}
+		if e.Footer != nil {
+			suffix := fmt.Sprintf(" • Page %d/%d", i+1, count)
+
+			footer = *e.Footer
+			footer.Text = truncate(footer.Text, maxEmbedFooterText-utf8.RuneCountInString(suffix)) + suffix
+		}
+
+		page.Footer = &footer
+		pages[i] = page
+	}
+
+	return pages
+}
+
+func splitLines(content string, limit int) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	var (
+		output  []string
+		current strings.Builder
+	)
+
+	for line := range strings.Lines(content) {
+		for utf8.RuneCountInString(line) > limit {
+			if current.Len() != 0 {
+				output = append(output, current.String())
+				current.Reset()
+			}
+
+			runes := []rune(line)
+			output = append(output, string(runes[:limit]))
+			line = string(runes[limit:])
+		}
+
+		if utf8.RuneCountInString(current.String())+utf8.RuneCountInString(line) > limit {
+			output = append(output, current.String())
+			current.Reset()
+		}
+
+		current.WriteString(line)
+	}
+
+	if current.Len() != 0 {
+		output = append(output, current.String())
+	}
+
+	return output
+}
@@ -0,0 +1,195 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+const entitlementsPageSize = 100
+
+type EntitlementType uint
+
+const (
+	PurchaseEntitlement                EntitlementType = 1
+	PremiumSubscriptionEntitlement     EntitlementType = 2
+	DeveloperGiftEntitlement           EntitlementType = 3
+	TestModePurchaseEntitlement        EntitlementType = 4
+	FreePurchaseEntitlement            EntitlementType = 5
+	UserGiftEntitlement                EntitlementType = 6
+	PremiumPurchaseEntitlement         EntitlementType = 7
+	ApplicationSubscriptionEntitlement EntitlementType = 8
+)
+
+type Entitlement struct {
+	StartsAt      *time.Time      `json:"starts_at,omitempty"`
+	EndsAt        *time.Time      `json:"ends_at,omitempty"`
+	ID            string          `json:"id"`
+	SKUID         string          `json:"sku_id"`
+	ApplicationID string          `json:"application_id"`
+	UserID        string          `json:"user_id,omitempty"`
+	GuildID       string          `json:"guild_id,omitempty"`
+	Type          EntitlementType `json:"type"`
+	Deleted       bool            `json:"deleted"`
+	Consumed      bool            `json:"consumed,omitempty"`
+}
+
+// Active checks if the entitlement currently grants access to its SKU
+func (e Entitlement) Active(now time.Time) bool {
+	if e.Deleted || e.Consumed {
+		return false
+	}
+
+	if e.StartsAt != nil && now.Before(*e.StartsAt) {
+		return false
+	}
+
+	return e.EndsAt == nil || now.Before(*e.EndsAt)
+}
+
+// HasEntitlement checks if the invoker or the guild owns an active entitlement to one of the SKUs
+func (i InteractionRequest) HasEntitlement(skuIDs ...string) bool {
+	now := time.Now()
+
+	return slices.ContainsFunc(i.Entitlements, func(entitlement Entitlement) bool {
+		return entitlement.Active(now) && slices.Contains(skuIDs, entitlement.SKUID)
+	})
+}
+
+// NewPremiumRequired creates an ephemeral response inviting the user to purchase the SKU
+func NewPremiumRequired(content, skuID string) InteractionResponse {
+	return NewResponse(ChannelMessageWithSource, content).Ephemeral().AddComponent(NewActionRow(NewPremiumButton(skuID)))
+}
+
+type SKUType uint
+
+const (
+	DurableSKU           SKUType = 2
+	ConsumableSKU        SKUType = 3
+	SubscriptionSKU      SKUType = 5
+	SubscriptionGroupSKU SKUType = 6
+)
+
+type SKUFlags uint
+
+const (
+	AvailableSKU         SKUFlags = 1 << 2
+	GuildSubscriptionSKU SKUFlags = 1 << 7
+	UserSubscriptionSKU  SKUFlags = 1 << 8
+)
+
+type SKU struct {
+	ID            string   `json:"id"`
+	ApplicationID string   `json:"application_id"`
+	Name          string   `json:"name"`
+	Slug          string   `json:"slug"`
+	Type          SKUType  `json:"type"`
+	Flags         SKUFlags `json:"flags"`
+}
+
+func (s Service) SKUs(ctx context.Context, req request.Request) ([]SKU, error) {
+	output, err := read[[]SKU](ctx, req.Path("/applications/%s/skus", s.applicationID).Method(http.MethodGet), nil)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return output, nil
+}
+
+type EntitlementFilter struct {
+	UserID       string
+	GuildID      string
+	SKUIDs       []string
+	ExcludeEnded bool
+}
+
+func (f EntitlementFilter) query() url.Values {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(entitlementsPageSize))
+
+	if len(f.UserID) != 0 {
+		query.Set("user_id", f.UserID)
+	}
+
+	if len(f.GuildID) != 0 {
+		query.Set("guild_id", f.GuildID)
+	}
+
+	if len(f.SKUIDs) != 0 {
+		query.Set("sku_ids", strings.Join(f.SKUIDs, ","))
+	}
+
+	if f.ExcludeEnded {
+		query.Set("exclude_ended", "true")
+	}
+
+	return query
+}
+
+// Entitlements sends to the output every entitlement of the application matching the filter
+func (s Service) Entitlements(ctx context.Context, req request.Request, filter EntitlementFilter, output chan<- Entitlement) error {
+	baseURL := fmt.Sprintf("/applications/%s/entitlements", s.applicationID)
+	query := filter.query()
+
+	for {
+		entitlements, err := read[[]Entitlement](ctx, req.Path(baseURL+"?"+query.Encode()).Method(http.MethodGet), nil)
+		if err != nil {
+			return fmt.Errorf("list: %w", err)
+		}
+
+		for _, entitlement := range entitlements {
+			output <- entitlement
+		}
+
+		if len(entitlements) < entitlementsPageSize {
+			return nil
+		}
+
+		query.Set("after", entitlements[len(entitlements)-1].ID)
+	}
+}
+
+type EntitlementOwnerType uint
+
+const (
+	GuildOwner EntitlementOwnerType = 1
+	UserOwner  EntitlementOwnerType = 2
+)
+
+// CreateTestEntitlement grants the SKU to a guild or a user without payment, for testing purpose
+func (s Service) CreateTestEntitlement(ctx context.Context, req request.Request, skuID, ownerID string, ownerType EntitlementOwnerType) (Entitlement, error) {
+	output, err := read[Entitlement](ctx, req.Path("/applications/%s/entitlements", s.applicationID).Method(http.MethodPost), map[string]any{
+		"sku_id":     skuID,
+		"owner_id":   ownerID,
+		"owner_type": ownerType,
+	})
+	if err != nil {
+		return output, fmt.Errorf("create: %w", err)
+	}
+
+	return output, nil
+}
+
+func (s Service) DeleteTestEntitlement(ctx context.Context, req request.Request, entitlementID string) error {
+	if err := discard(ctx, req.Path("/applications/%s/entitlements/%s", s.applicationID, entitlementID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeEntitlement marks a one-time purchase as used
+func (s Service) ConsumeEntitlement(ctx context.Context, req request.Request, entitlementID string) error {
+	if err := discard(ctx, req.Path("/applications/%s/entitlements/%s/consume", s.applicationID, entitlementID).Method(http.MethodPost), nil); err != nil {
+		return fmt.Errorf("consume: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ViBiOh/ChatPotte/discord/format"
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+const eventUsersPageSize = 100
+
+type ScheduledEventEntityType uint
+
+const (
+	StageInstanceEvent ScheduledEventEntityType = 1
+	VoiceEvent         ScheduledEventEntityType = 2
+	ExternalEvent      ScheduledEventEntityType = 3
+)
+
+type ScheduledEventStatus uint
+
+const (
+	ScheduledEventScheduled ScheduledEventStatus = 1
+	ScheduledEventActive    ScheduledEventStatus = 2
+	ScheduledEventCompleted ScheduledEventStatus = 3
+	ScheduledEventCanceled  ScheduledEventStatus = 4
+)
+
+const guildOnlyPrivacyLevel = 2
+
+type ScheduledEventMetadata struct {
+	Location string `json:"location,omitempty"`
+}
+
+type ScheduledEvent struct {
+	ScheduledStartTime time.Time                `json:"scheduled_start_time"`
+	ScheduledEndTime   *time.Time               `json:"scheduled_end_time,omitempty"`
+	EntityMetadata     *ScheduledEventMetadata  `json:"entity_metadata,omitempty"`
+	ID                 string                   `json:"id,omitempty"`
+	GuildID            string                   `json:"guild_id,omitempty"`
+	ChannelID          string                   `json:"channel_id,omitempty"`
+	CreatorID          string                   `json:"creator_id,omitempty"`
+	Name               string                   `json:"name"`
+	Description        string                   `json:"description,omitempty"`
+	Image              string                   `json:"image,omitempty"` // hash of the cover when read, data URI of the image when written
+	EntityType         ScheduledEventEntityType `json:"entity_type"`
+	Status             ScheduledEventStatus     `json:"status,omitempty"`
+	PrivacyLevel       int                      `json:"privacy_level"`
+	UserCount          int                      `json:"user_count,omitempty"`
+}
+
+// scheduledEventPayload holds the writable fields of an event
+type scheduledEventPayload struct {
+	ScheduledStartTime time.Time                `json:"scheduled_start_time"`
+	ScheduledEndTime   *time.Time               `json:"scheduled_end_time,omitempty"`
+	EntityMetadata     *ScheduledEventMetadata  `json:"entity_metadata,omitempty"`
+	ChannelID          string                   `json:"channel_id,omitempty"`
+	Name               string                   `json:"name"`
+	Description        string                   `json:"description,omitempty"`
+	Image              string                   `json:"image,omitempty"`
+	EntityType         ScheduledEventEntityType `json:"entity_type"`
+	Status             ScheduledEventStatus     `json:"status,omitempty"`
+	PrivacyLevel       int                      `json:"privacy_level"`
+}
+
+// payload keeps the writable fields, the image being sent only when it's a new one and not the hash of the current one
+func (e ScheduledEvent) payload() scheduledEventPayload {
+	payload := scheduledEventPayload{
+		ScheduledStartTime: e.ScheduledStartTime,
+		ScheduledEndTime:   e.ScheduledEndTime,
+		EntityMetadata:     e.EntityMetadata,
+		ChannelID:          e.ChannelID,
+		Name:               e.Name,
+		Description:        e.Description,
+		EntityType:         e.EntityType,
+		Status:             e.Status,
+		PrivacyLevel:       e.PrivacyLevel,
+	}
+
+	if strings.HasPrefix(e.Image, "data:") {
+		payload.Image = e.Image
+	}
+
+	return payload
+}
+
+// NewChannelEvent creates an event held in a stage or voice channel
+func NewChannelEvent(entityType ScheduledEventEntityType, channelID, name string, start time.Time) ScheduledEvent {
+	return ScheduledEvent{
+		EntityType:         entityType,
+		ChannelID:          channelID,
+		Name:               name,
+		ScheduledStartTime: start,
+		PrivacyLevel:       guildOnlyPrivacyLevel,
+	}
+}
+
+// NewExternalEvent creates an event held outside of Discord, end time being required
+func NewExternalEvent(location, name string, start, end time.Time) ScheduledEvent {
+	return ScheduledEvent{
+		EntityType:         ExternalEvent,
+		EntityMetadata:     &ScheduledEventMetadata{Location: location},
+		Name:               name,
+		ScheduledStartTime: start,
+		ScheduledEndTime:   &end,
+		PrivacyLevel:       guildOnlyPrivacyLevel,
+	}
+}
+
+// URL returns the link opening the event in Discord
+func (e ScheduledEvent) URL() string {
+	return fmt.Sprintf("https://discord.com/events/%s/%s", e.GuildID, e.ID)
+}
+
+// Embed renders the event with its schedule and location
+func (e ScheduledEvent) Embed() Embed {
+	embed := NewEmbed(e.Name, e.Description).SetURL(e.URL()).SetColor(ColorBlurple)
+
+	schedule := format.Timestamp(e.ScheduledStartTime, format.LongDateTime)
+	if e.ScheduledEndTime != nil {
+		schedule += " - " + format.Timestamp(*e.ScheduledEndTime, format.LongDateTime)
+	}
+
+	embed = embed.AddInlineField("When", schedule).AddInlineField("Starts", format.Timestamp(e.ScheduledStartTime, format.RelativeTime))
+
+	switch {
+	case e.EntityMetadata != nil && len(e.EntityMetadata.Location) != 0:
+		embed = embed.AddInlineField("Where", e.EntityMetadata.Location)
+	case len(e.ChannelID) != 0:
+		embed = embed.AddInlineField("Where", format.ChannelMention(e.ChannelID))
+	}
+
+	if e.UserCount != 0 {
+		embed = embed.AddInlineField("Interested", fmt.Sprintf("%d", e.UserCount))
+	}
+
+	if len(e.Image) != 0 {
+		embed = embed.SetImage(fmt.Sprintf("https://cdn.discordapp.com/guild-events/%s/%s.png?size=1024", e.ID, e.Image))
+	}
+
+	return embed
+}
+
+// NewEventAnnouncement creates a response announcing the event, with buttons to open it and join its channel
+func NewEventAnnouncement(event ScheduledEvent) InteractionResponse {
+	buttons := []Component{NewLinkButton("Event", event.URL())}
+
+	if len(event.ChannelID) != 0 {
+		buttons = append(buttons, NewLinkButton("Join", fmt.Sprintf("https://discord.com/channels/%s/%s", event.GuildID, event.ChannelID)))
+	}
+
+	return NewResponse(ChannelMessageWithSource, "").AddEmbed(event.Embed()).AddComponent(NewActionRow(buttons...))
+}
+
+func (s Service) ScheduledEvents(ctx context.Context, req request.Request, guildID string) ([]ScheduledEvent, error) {
+	output, err := read[[]ScheduledEvent](ctx, req.Path(fmt.Sprintf("/guilds/%s/scheduled-events?with_user_count=true", guildID)).Method(http.MethodGet), nil)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return output, nil
+}
+
+func (s Service) ScheduledEvent(ctx context.Context, req request.Request, guildID, eventID string) (ScheduledEvent, error) {
+	output, err := read[ScheduledEvent](ctx, req.Path(fmt.Sprintf("/guilds/%s/scheduled-events/%s?with_user_count=true", guildID, eventID)).Method(http.MethodGet), nil)
+	if err != nil {
+		return output, fmt.Errorf("get: %w", err)
+	}
+
+	return output, nil
+}
+
+func (s Service) CreateScheduledEvent(ctx context.Context, req request.Request, guildID string, event ScheduledEvent) (ScheduledEvent, error) {
+	output, err := read[ScheduledEvent](ctx, req.Path("/guilds/%s/scheduled-events", guildID).Method(http.MethodPost), event.payload())
+	if err != nil {
+		return output, fmt.Errorf("create: %w", err)
+	}
+
+	return output, nil
+}
+
+// UpdateScheduledEvent updates the event, setting its status allows to start, end or cancel it
+func (s Service) UpdateScheduledEvent(ctx context.Context, req request.Request, event ScheduledEvent) (ScheduledEvent, error) {
+	output, err := read[ScheduledEvent](ctx, req.Path("/guilds/%s/scheduled-events/%s", event.GuildID, event.ID).Method(http.MethodPatch), event.payload())
+	if err != nil {
+		return output, fmt.Errorf("update: %w", err)
+	}
+
+	return output, nil
+}
+
+func (s Service) DeleteScheduledEvent(ctx context.Context, req request.Request, event ScheduledEvent) error {
+	if err := discard(ctx, req.Path("/guilds/%s/scheduled-events/%s", event.GuildID, event.ID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+type scheduledEventUser struct {
+	User User `json:"user"`
+}
+
+// ScheduledEventUsers sends to the output every user interested in the event
+func (s Service) ScheduledEventUsers(ctx context.Context, req request.Request, event ScheduledEvent, output chan<- User) error {
+	baseURL := fmt.Sprintf("/guilds/%s/scheduled-events/%s/users", event.GuildID, event.ID)
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(eventUsersPageSize))
+
+	for {
+		users, err := read[[]scheduledEventUser](ctx, req.Path(baseURL+"?"+query.Encode()).Method(http.MethodGet), nil)
+		if err != nil {
+			return fmt.Errorf("list: %w", err)
+		}
+
+		for _, user := range users {
+			output <- user.User
+		}
+
+		if len(users) < eventUsersPageSize {
+			return nil
+		}
+
+		query.Set("after", users[len(users)-1].User.ID)
+	}
+}
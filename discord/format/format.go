@@ -0,0 +1,187 @@
+// Package format provides helpers to write Discord markdown, mentions and timestamps.
+//
+// Escaping only prevents formatting: pair it with `discord.NoMentions()` or `discord.ExplicitMentions()` to control who is pinged.
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const zeroWidthSpace = "\u200b"
+
+type TimestampStyle string
+
+const (
+	ShortTime     TimestampStyle = "t"
+	LongTime      TimestampStyle = "T"
+	ShortDate     TimestampStyle = "d"
+	LongDate      TimestampStyle = "D"
+	ShortDateTime TimestampStyle = "f"
+	LongDateTime  TimestampStyle = "F"
+	RelativeTime  TimestampStyle = "R"
+)
+
+var (
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		"*", `\*`,
+		"_", `\_`,
+		"~", `\~`,
+		"`", "\\`",
+		"|", `\|`,
+		">", `\>`,
+		"#", `\#`,
+		"-", `\-`,
+		"[", `\[`,
+		"]", `\]`,
+		"(", `\(`,
+		")", `\)`,
+		"@everyone", "@"+zeroWidthSpace+"everyone",
+		"@here", "@"+zeroWidthSpace+"here",
+	)
+
+	markdownStripper = strings.NewReplacer(
+		`\`, "",
+		"*", "",
+		"_", "",
+		"~", "",
+		"`", "",
+		"|", "",
+		"@everyone", "@"+zeroWidthSpace+"everyone",
+		"@here", "@"+zeroWidthSpace+"here",
+	)
+)
+
+// Escape prevents the text from being interpreted as markdown, and neutralizes `@everyone` and `@here`
+func Escape(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// Strip removes markdown from the text: emphasis, code, spoilers, headings, quotes and lists markers
+func Strip(text string) string {
+	var output strings.Builder
+
+	for line := range strings.Lines(text) {
+		trimmed := strings.TrimLeft(line, " ")
+
+		for _, prefix := range []string{"-# ", "### ", "## ", "# ", ">>> ", "> ", "- ", "* "} {
+			if rest, ok := strings.CutPrefix(trimmed, prefix); ok {
+				line = rest
+				break
+			}
+		}
+
+		output.WriteString(line)
+	}
+
+	return markdownStripper.Replace(output.String())
+}
+
+func UserMention(id string) string {
+	return fmt.Sprintf("<@%s>", id)
+}
+
+func RoleMention(id string) string {
+	return fmt.Sprintf("<@&%s>", id)
+}
+
+func ChannelMention(id string) string {
+	return fmt.Sprintf("<#%s>", id)
+}
+
+// CommandMention creates a clickable slash command, name can contain subcommands, e.g. `config set`
+func CommandMention(name, id string) string {
+	return fmt.Sprintf("</%s:%s>", name, id)
+}
+
+// Timestamp displays the time in the reader's timezone, with Discord's default style when empty
+func Timestamp(t time.Time, style TimestampStyle) string {
+	if len(style) == 0 {
+		return fmt.Sprintf("<t:%d>", t.Unix())
+	}
+
+	return fmt.Sprintf("<t:%d:%s>", t.Unix(), style)
+}
+
+// Heading creates a title, level being clamped between 1 and 3
+func Heading(level int, text string) string {
+	return strings.Repeat("#", min(max(level, 1), 3)) + " " + text
+}
+
+// Subtext creates a small and greyed text
+func Subtext(text string) string {
+	return "-# " + text
+}
+
+func Bold(text string) string {
+	return "**" + text + "**"
+}
+
+func Italic(text string) string {
+	return "*" + text + "*"
+}
+
+func Underline(text string) string {
+	return "__" + text + "__"
+}
+
+func Strikethrough(text string) string {
+	return "~~" + text + "~~"
+}
+
+func Spoiler(text string) string {
+	return "||" + text + "||"
+}
+
+// Link creates a masked link
+func Link(text, url string) string {
+	return fmt.Sprintf("[%s](<%s>)", text, url)
+}
+
+// Quote quotes every line of the text
+func Quote(text string) string {
+	var output strings.Builder
+
+	for line := range strings.Lines(text) {
+		output.WriteString("> ")
+		output.WriteString(line)
+	}
+
+	return output.String()
+}
+
+func List(items ...string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = "- " + item
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func OrderedList(items ...string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, item)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// InlineCode wraps the text in backticks, doubling them if the text already contains one
+func InlineCode(text string) string {
+	if !strings.Contains(text, "`") {
+		return "`" + text + "`"
+	}
+
+	return "`` " + text + " ``"
+}
+
+// CodeBlock wraps the code in a block highlighted for the given language, neutralizing inner fences
+func CodeBlock(language, code string) string {
+	code = strings.ReplaceAll(code, "```", "`"+zeroWidthSpace+"``")
+
+	return "```" + language + "\n" + strings.TrimSuffix(code, "\n") + "\n```"
+}
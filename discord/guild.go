@@ -4,44 +4,109 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
-	"github.com/ViBiOh/httputils/v4/pkg/httpjson"
 	"github.com/ViBiOh/httputils/v4/pkg/request"
 )
 
 type Guild struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	OwnerID string `json:"owner_id,omitempty"`
 }
 
+type ChannelType uint
+
+const (
+	GuildTextChannel          ChannelType = 0
+	DMChannel                 ChannelType = 1
+	GuildVoiceChannel         ChannelType = 2
+	GroupDMChannel            ChannelType = 3
+	GuildCategoryChannel      ChannelType = 4
+	GuildAnnouncementChannel  ChannelType = 5
+	AnnouncementThreadChannel ChannelType = 10
+	PublicThreadChannel       ChannelType = 11
+	PrivateThreadChannel      ChannelType = 12
+	GuildStageVoiceChannel    ChannelType = 13
+	GuildDirectoryChannel     ChannelType = 14
+	GuildForumChannel         ChannelType = 15
+	GuildMediaChannel         ChannelType = 16
+)
+
 type Channel struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ThreadMetadata       *ThreadMetadata       `json:"thread_metadata,omitempty"`
+	ID                   string                `json:"id"`
+	Name                 string                `json:"name"`
+	GuildID              string                `json:"guild_id,omitempty"`
+	ParentID             string                `json:"parent_id,omitempty"`
+	OwnerID              string                `json:"owner_id,omitempty"`
+	Topic                string                `json:"topic,omitempty"`
+	PermissionOverwrites []PermissionOverwrite `json:"permission_overwrites,omitempty"`
+	AppliedTags          []string              `json:"applied_tags,omitempty"`
+	Type                 ChannelType           `json:"type"`
+	MessageCount         int                   `json:"message_count,omitempty"`
+}
+
+type ThreadMetadata struct {
+	ArchiveTimestamp    time.Time `json:"archive_timestamp"`
+	AutoArchiveDuration int       `json:"auto_archive_duration"`
+	Archived            bool      `json:"archived"`
+	Locked              bool      `json:"locked"`
+	Invitable           bool      `json:"invitable,omitempty"`
+}
+
+// IsThread checks if the channel is a thread or a forum post
+func (c Channel) IsThread() bool {
+	return c.Type == AnnouncementThreadChannel || c.Type == PublicThreadChannel || c.Type == PrivateThreadChannel
+}
+
+// HasThreads checks if the channel can contain threads
+func (c Channel) HasThreads() bool {
+	return c.Type == GuildTextChannel || c.Type == GuildAnnouncementChannel || c.Type == GuildForumChannel || c.Type == GuildMediaChannel
+}
+
+// HasMessages checks if the channel directly contains messages
+func (c Channel) HasMessages() bool {
+	switch c.Type {
+	case GuildCategoryChannel, GuildDirectoryChannel, GuildForumChannel, GuildMediaChannel:
+		return false
+	default:
+		return true
+	}
 }
 
 func CurrentUser(ctx context.Context, req request.Request) (User, error) {
-	resp, err := req.Path("/users/@me").Method(http.MethodGet).Send(ctx, nil)
+	user, err := read[User](ctx, req.Path("/users/@me").Method(http.MethodGet), nil)
 	if err != nil {
-		return User{}, fmt.Errorf("get: %w", err)
+		return user, fmt.Errorf("get: %w", err)
 	}
 
-	return httpjson.Read[User](resp)
+	return user, nil
 }
 
 func Guilds(ctx context.Context, req request.Request) ([]Guild, error) {
-	resp, err := req.Path("/users/@me/guilds").Method(http.MethodGet).Send(ctx, nil)
+	guilds, err := read[[]Guild](ctx, req.Path("/users/@me/guilds").Method(http.MethodGet), nil)
 	if err != nil {
 		return nil, fmt.Errorf("list: %w", err)
 	}
 
-	return httpjson.Read[[]Guild](resp)
+	return guilds, nil
+}
+
+func GetGuild(ctx context.Context, req request.Request, guildID string) (Guild, error) {
+	guild, err := read[Guild](ctx, req.Path("/guilds/%s", guildID).Method(http.MethodGet), nil)
+	if err != nil {
+		return guild, fmt.Errorf("get: %w", err)
+	}
+
+	return guild, nil
 }
 
 func Channels(ctx context.Context, req request.Request, guild Guild) ([]Channel, error) {
-	resp, err := req.Path("/guilds/%s/channels", guild.ID).Method(http.MethodGet).Send(ctx, nil)
+	channels, err := read[[]Channel](ctx, req.Path("/guilds/%s/channels", guild.ID).Method(http.MethodGet), nil)
 	if err != nil {
 		return nil, fmt.Errorf("list: %w", err)
 	}
 
-	return httpjson.Read[[]Channel](resp)
+	return channels, nil
 }
@@ -0,0 +1,117 @@
+package discord
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const authorizeURL = "https://discord.com/oauth2/authorize"
+
+type IntegrationType int
+
+const (
+	GuildInstall IntegrationType = 0
+	UserInstall  IntegrationType = 1
+)
+
+// Invite describes the OAuth authorize URL used to install the application
+type Invite struct {
+	IntegrationType    *IntegrationType
+	GuildID            string
+	RedirectURI        string
+	State              string
+	Scopes             []string
+	Permissions        Permissions
+	DisableGuildSelect bool
+}
+
+// NewBotInvite creates an invite adding the bot with the given permissions to a guild
+func NewBotInvite(permissions Permissions) Invite {
+	return Invite{
+		Scopes:      []string{"bot", "applications.commands"},
+		Permissions: permissions,
+	}
+}
+
+// ForGuild preselects the guild in the install dialog, and prevents from changing it if locked
+func (i Invite) ForGuild(guildID string, locked bool) Invite {
+	i.GuildID = guildID
+	i.DisableGuildSelect = locked
+
+	return i
+}
+
+func (i Invite) WithIntegrationType(integrationType IntegrationType) Invite {
+	i.IntegrationType = &integrationType
+
+	return i
+}
+
+// WithRedirect redirects to the given URI with an authorization code once installed
+func (i Invite) WithRedirect(redirectURI string) Invite {
+	i.RedirectURI = redirectURI
+
+	return i
+}
+
+// URL builds the authorize URL for the given application
+func (i Invite) URL(clientID string) string {
+	query := url.Values{}
+	query.Set("client_id", clientID)
+
+	if len(i.Scopes) != 0 {
+		query.Set("scope", strings.Join(i.Scopes, " "))
+	}
+
+	if i.Permissions != 0 {
+		query.Set("permissions", strconv.FormatUint(uint64(i.Permissions), 10))
+	}
+
+	if len(i.GuildID) != 0 {
+		query.Set("guild_id", i.GuildID)
+
+		if i.DisableGuildSelect {
+			query.Set("disable_guild_select", "true")
+		}
+	}
+
+	if i.IntegrationType != nil {
+		query.Set("integration_type", strconv.Itoa(int(*i.IntegrationType)))
+	}
+
+	if len(i.RedirectURI) != 0 {
+		query.Set("redirect_uri", i.RedirectURI)
+		query.Set("response_type", "code")
+	}
+
+	if len(i.State) != 0 {
+		query.Set("state", i.State)
+	}
+
+	return authorizeURL + "?" + query.Encode()
+}
+
+// InviteURL builds the authorize URL of the service's application
+func (s Service) InviteURL(invite Invite) string {
+	return invite.URL(s.oauthClientID())
+}
+
+// WithInstall serves the invite as a redirection on `GET /install` of the mux
+func (s Service) WithInstall(invite Invite) Service {
+	s.install = &invite
+	return s
+}
+
+func (s Service) handleInstall(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, s.InviteURL(*s.install), http.StatusFound)
+}
+
+func (s Service) oauthClientID() string {
+	if len(s.clientID) != 0 {
+		return s.clientID
+	}
+
+	return s.applicationID
+}
@@ -0,0 +1,329 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	maxActionRowComponents    = 5
+	maxSectionTexts           = 3
+	maxMediaGalleryItems      = 10
+	maxComponentsV2Components = 40
+)
+
+type SeparatorSpacing uint
+
+const (
+	SmallSpacing SeparatorSpacing = 1
+	LargeSpacing SeparatorSpacing = 2
+)
+
+type UnfurledMedia struct {
+	URL string `json:"url"`
+}
+
+type MediaGalleryItem struct {
+	Media       UnfurledMedia `json:"media"`
+	Description string        `json:"description,omitempty"`
+	Spoiler     bool          `json:"spoiler,omitempty"`
+}
+
+func NewMediaGalleryItem(url, description string) MediaGalleryItem {
+	return MediaGalleryItem{
+		Media:       UnfurledMedia{URL: url},
+		Description: description,
+	}
+}
+
+func NewActionRow(components ...Component) Component {
+	return Component{
+		Type:       ActionRowType,
+		Components: components,
+	}
+}
+
+// NewContainer creates a box around the given components, like an embed
+func NewContainer(components ...Component) Component {
+	return Component{
+		Type:       ContainerType,
+		Components: components,
+	}
+}
+
+// NewSection creates texts displayed next to an accessory, a thumbnail or a button
+func NewSection(accessory Component, texts ...string) Component {
+	components := make([]Component, len(texts))
+	for i, text := range texts {
+		components[i] = NewTextDisplay(text)
+	}
+
+	return Component{
+		Type:       SectionType,
+		Accessory:  &accessory,
+		Components: components,
+	}
+}
+
+// NewTextDisplay creates a markdown text
+func NewTextDisplay(content string) Component {
+	return Component{
+		Type:    TextDisplayType,
+		Content: content,
+	}
+}
+
+func NewThumbnail(url, description string) Component {
+	return Component{
+		Type:        ThumbnailType,
+		Media:       &UnfurledMedia{URL: url},
+		Description: description,
+	}
+}
+
+func NewMediaGallery(items ...MediaGalleryItem) Component {
+	return Component{
+		Type:  MediaGalleryType,
+		Items: items,
+	}
+}
+
+// NewFile displays an uploaded attachment, referenced by its filename
+func NewFile(filename string) Component {
+	return Component{
+		Type: FileType,
+		File: &UnfurledMedia{URL: "attachment://" + filename},
+	}
+}
+
+func NewSeparator(divider bool, spacing SeparatorSpacing) Component {
+	return Component{
+		Type:    SeparatorType,
+		Divider: &divider,
+		Spacing: spacing,
+	}
+}
+
+func (c Component) WithAccentColor(color int) Component {
+	c.AccentColor = &color
+	return c
+}
+
+func (c Component) WithSpoiler() Component {
+	c.Spoiler = true
+	return c
+}
+
+// ComponentsV2 flags the response for using layout components. Content and embeds can't be used anymore.
+func (d InteractionDataResponse) ComponentsV2() InteractionDataResponse {
+	d.Flags |= ComponentsV2Message
+	return d
+}
+
+func (i InteractionResponse) ComponentsV2() InteractionResponse {
+	i.Data = i.Data.ComponentsV2()
+	return i
+}
+
+// CheckComponents checks that components respect Discord's nesting rules, depending on the message's flags
+func (d InteractionDataResponse) CheckComponents() error {
+	componentsV2 := d.Flags&ComponentsV2Message != 0
+
+	var errs []error
+
+	if componentsV2 {
+		if len(d.Content) != 0 {
+			errs = append(errs, errors.New("content can't be used with components v2"))
+		}
+
+		if len(d.Embeds) != 0 {
+			errs = append(errs, errors.New("embeds can't be used with components v2"))
+		}
+	}
+
+	return errors.Join(append(errs, CheckComponents(d.Components, componentsV2))...)
+}
+
+// CheckComponents checks that top-level components, and their children, respect Discord's nesting rules
+func CheckComponents(components []Component, componentsV2 bool) error {
+	if !componentsV2 {
+		var errs []error
+
+		if len(components) > maxActionRowComponents {
+			errs = append(errs, fmt.Errorf("at most %d action rows are allowed, got %d", maxActionRowComponents, len(components)))
+		}
+
+		for i, component := range components {
+			if component.Type != ActionRowType {
+				errs = append(errs, fmt.Errorf("components[%d]: only action rows are allowed at top-level without components v2", i))
+				continue
+			}
+
+			errs = append(errs, checkComponent(fmt.Sprintf("components[%d]", i), component))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	var errs []error
+
+	if count := countComponents(components); count > maxComponentsV2Components {
+		errs = append(errs, fmt.Errorf("at most %d components are allowed, got %d", maxComponentsV2Components, count))
+	}
+
+	for i, component := range components {
+		path := fmt.Sprintf("components[%d]", i)
+
+		if !slices.Contains([]componentType{ActionRowType, SectionType, TextDisplayType, MediaGalleryType, FileType, SeparatorType, ContainerType}, component.Type) {
+			errs = append(errs, fmt.Errorf("%s: type %d is not allowed at top-level", path, component.Type))
+			continue
+		}
+
+		errs = append(errs, checkComponent(path, component))
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkComponent(path string, component Component) error {
+	var errs []error
+
+	switch component.Type {
+	case ActionRowType:
+		if len(component.Components) == 0 || len(component.Components) > maxActionRowComponents {
+			errs = append(errs, fmt.Errorf("%s: action row must contain between 1 and %d components, got %d", path, maxActionRowComponents, len(component.Components)))
+		}
+
+		errs = append(errs, checkChildren(path, component.Components, isInteractive)...)
+
+	case SectionType:
+		if len(component.Components) == 0 || len(component.Components) > maxSectionTexts {
+			errs = append(errs, fmt.Errorf("%s: section must contain between 1 and %d text displays, got %d", path, maxSectionTexts, len(component.Components)))
+		}
+
+		errs = append(errs, checkChildren(path, component.Components, func(child Component) bool { return child.Type == TextDisplayType })...)
+
+		if component.Accessory == nil {
+			errs = append(errs, fmt.Errorf("%s: section must have an accessory", path))
+		} else if component.Accessory.Type != ThumbnailType && component.Accessory.Type != buttonType {
+			errs = append(errs, fmt.Errorf("%s.accessory: only thumbnail or button is allowed, got type %d", path, component.Accessory.Type))
+		} else {
+			errs = append(errs, checkComponent(path+".accessory", *component.Accessory))
+		}
+
+	case ContainerType:
+		if len(component.Components) == 0 {
+			errs = append(errs, fmt.Errorf("%s: container must contain at least one component", path))
+		}
+
+		errs = append(errs, checkChildren(path, component.Components, func(child Component) bool {
+			return slices.Contains([]componentType{ActionRowType, TextDisplayType, SectionType, MediaGalleryType, SeparatorType, FileType}, child.Type)
+		})...)
+
+	case TextDisplayType:
+		if len(strings.TrimSpace(component.Content)) == 0 {
+			errs = append(errs, fmt.Errorf("%s: text display must have content", path))
+		}
+
+	case ThumbnailType:
+		if component.Media == nil || len(component.Media.URL) == 0 {
+			errs = append(errs, fmt.Errorf("%s: thumbnail must have a media", path))
+		}
+
+	case MediaGalleryType:
+		if len(component.Items) == 0 || len(component.Items) > maxMediaGalleryItems {
+			errs = append(errs, fmt.Errorf("%s: media gallery must contain between 1 and %d items, got %d", path, maxMediaGalleryItems, len(component.Items)))
+		}
+
+	case FileType:
+		if component.File == nil || !strings.HasPrefix(component.File.URL, "attachment://") {
+			errs = append(errs, fmt.Errorf("%s: file must reference an attachment", path))
+		}
+
+	case buttonType:
+		errs = append(errs, checkButton(path, component))
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkButton(path string, button Component) error {
+	var errs []error
+
+	switch button.Style {
+	case LinkButton:
+		if len(button.URL) == 0 {
+			errs = append(errs, fmt.Errorf("%s: link button must have an url", path))
+		}
+
+		if len(button.CustomID) != 0 {
+			errs = append(errs, fmt.Errorf("%s: link button can't have a custom_id", path))
+		}
+
+	case PremiumButton:
+		if len(button.SKUID) == 0 {
+			errs = append(errs, fmt.Errorf("%s: premium button must have a sku_id", path))
+		}
+
+		if len(button.CustomID) != 0 || len(button.URL) != 0 || len(button.Label) != 0 || button.Emoji != nil {
+			errs = append(errs, fmt.Errorf("%s: premium button can't have custom_id, url, label or emoji", path))
+		}
+
+	default:
+		if len(button.CustomID) == 0 {
+			errs = append(errs, fmt.Errorf("%s: button must have a custom_id", path))
+		}
+
+		if len(button.URL) != 0 {
+			errs = append(errs, fmt.Errorf("%s: only link button can have an url", path))
+		}
+	}
+
+	if len(button.CustomID) > customIDMaxLen {
+		errs = append(errs, fmt.Errorf("%s: custom_id exceeds %d characters", path, customIDMaxLen))
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkChildren(path string, children []Component, allowed func(Component) bool) []error {
+	var errs []error
+
+	for i, child := range children {
+		childPath := fmt.Sprintf("%s.components[%d]", path, i)
+
+		if !allowed(child) {
+			errs = append(errs, fmt.Errorf("%s: type %d is not allowed here", childPath, child.Type))
+			continue
+		}
+
+		errs = append(errs, checkComponent(childPath, child))
+	}
+
+	return errs
+}
+
+func isInteractive(component Component) bool {
+	switch component.Type {
+	case buttonType, stringSelectType, userSelectType, roleSelectType, mentionableSelectType, channelSelectType:
+		return true
+	default:
+		return false
+	}
+}
+
+func countComponents(components []Component) int {
+	count := len(components)
+
+	for _, component := range components {
+		count += countComponents(component.Components)
+
+		if component.Accessory != nil {
+			count++
+		}
+	}
+
+	return count
+}
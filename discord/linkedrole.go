@@ -0,0 +1,152 @@
+package discord
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+const (
+	roleConnectionScope = "role_connections.write"
+	oauthStateCookie    = "discord_oauth_state"
+	oauthStateTTL       = 10 * time.Minute
+)
+
+type RoleConnectionMetadataType int
+
+const (
+	IntegerLessThanOrEqualMetadata     RoleConnectionMetadataType = 1
+	IntegerGreaterThanOrEqualMetadata  RoleConnectionMetadataType = 2
+	IntegerEqualMetadata               RoleConnectionMetadataType = 3
+	IntegerNotEqualMetadata            RoleConnectionMetadataType = 4
+	DatetimeLessThanOrEqualMetadata    RoleConnectionMetadataType = 5
+	DatetimeGreaterThanOrEqualMetadata RoleConnectionMetadataType = 6
+	BooleanEqualMetadata               RoleConnectionMetadataType = 7
+	BooleanNotEqualMetadata            RoleConnectionMetadataType = 8
+)
+
+// RoleConnectionMetadata describes a value of the application that guilds can require to grant a linked role
+type RoleConnectionMetadata struct {
+	Key         string                     `json:"key"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Type        RoleConnectionMetadataType `json:"type"`
+}
+
+// RoleConnection is the user's data on the application, values are keyed by metadata key
+type RoleConnection struct {
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	PlatformName     string            `json:"platform_name,omitempty"`
+	PlatformUsername string            `json:"platform_username,omitempty"`
+}
+
+// RoleConnector computes the role connection of a user that completed the verification
+type RoleConnector func(context.Context, User) (RoleConnection, error)
+
+// WithLinkedRoles updates the role connection of users going through the `GET /verify` page of the mux.
+// The callback URL is the public URL of the mux's `GET /oauth` route, and must be registered as a redirect of the application.
+func (s Service) WithLinkedRoles(callbackURL string, connector RoleConnector) Service {
+	s.roleCallbackURL = callbackURL
+	s.roleConnector = connector
+	return s
+}
+
+func (s Service) RoleConnectionMetadata(ctx context.Context, req request.Request) ([]RoleConnectionMetadata, error) {
+	output, err := read[[]RoleConnectionMetadata](ctx, req.Path("/applications/%s/role-connections/metadata", s.applicationID).Method(http.MethodGet), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	return output, nil
+}
+
+// RegisterRoleConnectionMetadata replaces all the metadata records of the application, it requires a bot token
+func (s Service) RegisterRoleConnectionMetadata(ctx context.Context, req request.Request, records []RoleConnectionMetadata) ([]RoleConnectionMetadata, error) {
+	output, err := read[[]RoleConnectionMetadata](ctx, req.Path("/applications/%s/role-connections/metadata", s.applicationID).Method(http.MethodPut), records)
+	if err != nil {
+		return nil, fmt.Errorf("register: %w", err)
+	}
+
+	return output, nil
+}
+
+// UpdateRoleConnection updates the role connection of the user owning the token, that must have the `role_connections.write` scope
+func (s Service) UpdateRoleConnection(ctx context.Context, token OAuthToken, connection RoleConnection) (RoleConnection, error) {
+	output, err := read[RoleConnection](ctx, token.Client().Path("/users/@me/applications/%s/role-connection", s.applicationID).Method(http.MethodPut), connection)
+	if err != nil {
+		return output, fmt.Errorf("update: %w", err)
+	}
+
+	return output, nil
+}
+
+func (s Service) connectRoles(ctx context.Context, token OAuthToken) error {
+	user, err := CurrentUser(ctx, token.Client())
+	if err != nil {
+		return fmt.Errorf("current user: %w", err)
+	}
+
+	connection, err := s.roleConnector(ctx, user)
+	if err != nil {
+		return fmt.Errorf("connector: %w", err)
+	}
+
+	if _, err = s.UpdateRoleConnection(ctx, token, connection); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleVerify is the linked roles verification URL of the application. It redirects to the OAuth consent, Discord then redirecting
+// to the callback URL, served by handleOauth, which checks the state, updates the role connection and redirects to the website.
+func (s Service) handleVerify(w http.ResponseWriter, r *http.Request) {
+	state := rand.Text()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   int(oauthStateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	invite := Invite{
+		Scopes:      []string{"identify", roleConnectionScope},
+		RedirectURI: s.roleCallbackURL,
+		State:       state,
+	}
+
+	http.Redirect(w, r, s.InviteURL(invite), http.StatusFound)
+}
+
+// checkOAuthState compares the state of the callback with the one set by handleVerify, and clears it
+func checkOAuthState(w http.ResponseWriter, r *http.Request) error {
+	cookie, err := r.Cookie(oauthStateCookie)
+	if err != nil {
+		return errors.New("no state cookie")
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.URL.Query().Get("state"))) != 1 {
+		return errors.New("state mismatch")
+	}
+
+	return nil
+}
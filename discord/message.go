@@ -3,7 +3,10 @@ package discord
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,30 +14,160 @@ import (
 	"github.com/ViBiOh/httputils/v4/pkg/request"
 )
 
+const (
+	bulkDeleteMaxCount = 100
+	bulkDeleteMaxAge   = 14*24*time.Hour - time.Hour // keep a margin for clock skew
+	discordEpoch       = 1420070400000
+)
+
+type MessageType uint
+
+const (
+	DefaultMessage            MessageType = 0
+	ChannelPinnedMessage      MessageType = 6
+	ThreadCreatedMessage      MessageType = 18
+	ReplyMessage              MessageType = 19
+	ChatInputCommandMessage   MessageType = 20
+	ThreadStarterMessage      MessageType = 21
+	ContextMenuCommandMessage MessageType = 23
+)
+
 type Message struct {
-	ID        string    `json:"id"`
-	ChannelID string    `json:"channel_id"`
-	Timestamp time.Time `json:"timestamp"`
-	Author    User      `json:"author"`
-	Content   string    `json:"content"`
-	Embeds    []Embed   `json:"Embeds"`
+	Timestamp           time.Time            `json:"timestamp"`
+	Thread              *Channel             `json:"thread,omitempty"`
+	MessageReference    *MessageReference    `json:"message_reference,omitempty"`
+	InteractionMetadata *InteractionMetadata `json:"interaction_metadata,omitempty"`
+	Poll                *Poll                `json:"poll,omitempty"`
+	ID                  string               `json:"id"`
+	ChannelID           string               `json:"channel_id"`
+	Content             string               `json:"content"`
+	WebhookID           string               `json:"webhook_id,omitempty"`
+	Author              User                 `json:"author"`
+	Embeds              []Embed              `json:"embeds"`
+	Attachments         []MessageAttachment  `json:"attachments,omitempty"`
+	Mentions            []User               `json:"mentions,omitempty"`
+	Reactions           []Reaction           `json:"reactions,omitempty"`
+	Components          []Component          `json:"components,omitempty"`
+	Type                MessageType          `json:"type"`
+	Flags               int                  `json:"flags,omitempty"`
+	Pinned              bool                 `json:"pinned"`
 }
 
 func (m Message) String() string {
 	var output strings.Builder
+
+	if m.Pinned {
+		output.WriteString("📌 ")
+	}
+
 	fmt.Fprintf(&output, "[%s] %s: %s", m.Timestamp.Format(time.RFC3339), m.Author.Username, m.Content)
 
+	if m.MessageReference != nil && len(m.MessageReference.MessageID) != 0 {
+		fmt.Fprintf(&output, " (reply to %s)", m.MessageReference.MessageID)
+	}
+
+	if m.InteractionMetadata != nil {
+		fmt.Fprintf(&output, " (interaction of %s)", m.InteractionMetadata.User.Username)
+	}
+
 	for _, embed := range m.Embeds {
 		fmt.Fprintf(&output, ", %s - %s", embed.Title, embed.Description)
 	}
 
+	for _, attachment := range m.Attachments {
+		fmt.Fprintf(&output, ", 📎 %s", attachment.Filename)
+	}
+
+	for _, reaction := range m.Reactions {
+		fmt.Fprintf(&output, ", %s x%d", reaction.Emoji, reaction.Count)
+	}
+
+	if m.Poll != nil {
+		fmt.Fprintf(&output, ", 📊 %s", m.Poll.Question.Text)
+	}
+
+	if m.Thread != nil {
+		fmt.Fprintf(&output, ", 🧵 %s", m.Thread.Name)
+	}
+
 	return output.String()
 }
 
+// CreatedAt returns the message's timestamp, or the one encoded in its snowflake ID if missing
+func (m Message) CreatedAt() time.Time {
+	if !m.Timestamp.IsZero() {
+		return m.Timestamp
+	}
+
+	id, err := strconv.ParseUint(m.ID, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(int64(id>>22) + discordEpoch)
+}
+
 type User struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	Bot      bool   `json:"bot"`
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	GlobalName string `json:"global_name,omitempty"`
+	Avatar     string `json:"avatar,omitempty"`
+	Bot        bool   `json:"bot"`
+}
+
+type MessageAttachment struct {
+	ID          string `json:"id"`
+	Filename    string `json:"filename"`
+	Description string `json:"description,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	URL         string `json:"url"`
+	ProxyURL    string `json:"proxy_url,omitempty"`
+	Size        int64  `json:"size"`
+	Height      int    `json:"height,omitempty"`
+	Width       int    `json:"width,omitempty"`
+	Ephemeral   bool   `json:"ephemeral,omitempty"`
+}
+
+type Emoji struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Animated bool   `json:"animated,omitempty"`
+}
+
+func (e Emoji) String() string {
+	if len(e.ID) == 0 {
+		return e.Name
+	}
+
+	if e.Animated {
+		return fmt.Sprintf("<a:%s:%s>", e.Name, e.ID)
+	}
+
+	return fmt.Sprintf("<:%s:%s>", e.Name, e.ID)
+}
+
+type Reaction struct {
+	Emoji Emoji `json:"emoji"`
+	Count int   `json:"count"`
+	Me    bool  `json:"me"`
+}
+
+type MessageReference struct {
+	FailIfNotExists *bool  `json:"fail_if_not_exists,omitempty"`
+	MessageID       string `json:"message_id,omitempty"`
+	ChannelID       string `json:"channel_id,omitempty"`
+	GuildID         string `json:"guild_id,omitempty"`
+	Type            int    `json:"type,omitempty"`
+}
+
+type InteractionMetadata struct {
+	TargetUser                *User           `json:"target_user,omitempty"`
+	ID                        string          `json:"id"`
+	OriginalResponseMessageID string          `json:"original_response_message_id,omitempty"`
+	InteractedMessageID       string          `json:"interacted_message_id,omitempty"`
+	TargetMessageID           string          `json:"target_message_id,omitempty"`
+	User                      User            `json:"user"`
+	Type                      interactionType `json:"type"`
 }
 
 func (s Service) Messages(ctx context.Context, req request.Request, channelID string, output chan<- Message) error {
@@ -42,21 +175,11 @@ func (s Service) Messages(ctx context.Context, req request.Request, channelID st
 	nextURL := baseURL
 
 	for {
-	retry:
-		resp, err := req.Path(nextURL).Send(ctx, nil)
+		messages, err := read[[]Message](ctx, req.Path(nextURL), nil)
 		if err != nil {
-			if IsRetryable(ctx, resp) {
-				goto retry
-			}
-
 			return fmt.Errorf("list: %w", err)
 		}
 
-		messages, err := httpjson.Read[[]Message](resp)
-		if err != nil {
-			return fmt.Errorf("read: %w", err)
-		}
-
 		for _, message := range messages {
 			output <- message
 		}
@@ -70,18 +193,131 @@ func (s Service) Messages(ctx context.Context, req request.Request, channelID st
 }
 
 func (s Service) DeleteMessage(ctx context.Context, req request.Request, message Message) error {
-retry:
-	resp, err := req.Path("/channels/%s/messages/%s", message.ChannelID, message.ID).Method(http.MethodDelete).Send(ctx, nil)
+	if err := discard(ctx, req.Path("/channels/%s/messages/%s", message.ChannelID, message.ID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	s.invalidateCustomIDs(ctx, message.Components, nil)
+
+	return nil
+}
+
+func (s Service) CreateMessage(ctx context.Context, req request.Request, channelID string, data InteractionDataResponse) (Message, error) {
+	return s.writeMessage(ctx, req.Path("/channels/%s/messages", channelID).Method(http.MethodPost), data)
+}
+
+func (s Service) Reply(ctx context.Context, req request.Request, message Message, data InteractionDataResponse) (Message, error) {
+	return s.CreateMessage(ctx, req, message.ChannelID, data.ReplyTo(message))
+}
+
+func (s Service) EditMessage(ctx context.Context, req request.Request, message Message, data InteractionDataResponse) (Message, error) {
+	output, err := s.writeMessage(ctx, req.Path("/channels/%s/messages/%s", message.ChannelID, message.ID).Method(http.MethodPatch), data)
+	if err != nil {
+		return output, err
+	}
+
+	s.invalidateCustomIDs(ctx, message.Components, output.Components)
+
+	return output, nil
+}
+
+func (s Service) CrosspostMessage(ctx context.Context, req request.Request, message Message) (Message, error) {
+	output, err := read[Message](ctx, req.Path("/channels/%s/messages/%s/crosspost", message.ChannelID, message.ID).Method(http.MethodPost), nil)
 	if err != nil {
-		if IsRetryable(ctx, resp) {
-			goto retry
+		return output, fmt.Errorf("crosspost: %w", err)
+	}
+
+	return output, nil
+}
+
+func (s Service) PinMessage(ctx context.Context, req request.Request, message Message) error {
+	if err := discard(ctx, req.Path("/channels/%s/pins/%s", message.ChannelID, message.ID).Method(http.MethodPut), nil); err != nil {
+		return fmt.Errorf("pin: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) UnpinMessage(ctx context.Context, req request.Request, message Message) error {
+	if err := discard(ctx, req.Path("/channels/%s/pins/%s", message.ChannelID, message.ID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("unpin: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) writeMessage(ctx context.Context, req request.Request, data InteractionDataResponse) (Message, error) {
+	data = s.prepare(ctx, data)
+
+	resp, err := sendPayload(ctx, req, data, data)
+	if err != nil {
+		return Message{}, fmt.Errorf("send: %w", err)
+	}
+
+	return httpjson.Read[Message](resp)
+}
+
+type DeleteResult struct {
+	Err     error
+	Message Message
+}
+
+// BulkDelete deletes given messages, in batches for those that are eligible to bulk deletion and one by one for the others. The audit log reason is read from the context, see WithAuditLogReason
+func (s Service) BulkDelete(ctx context.Context, req request.Request, messages []Message) []DeleteResult {
+	results := make([]DeleteResult, 0, len(messages))
+
+	bulkLimit := time.Now().Add(-bulkDeleteMaxAge)
+	bulks := make(map[string][]Message)
+	var singles []Message
+
+	for _, message := range messages {
+		if message.CreatedAt().After(bulkLimit) {
+			bulks[message.ChannelID] = append(bulks[message.ChannelID], message)
+		} else {
+			singles = append(singles, message)
+		}
+	}
+
+	for _, channelID := range slices.Sorted(maps.Keys(bulks)) {
+		for batch := range slices.Chunk(bulks[channelID], bulkDeleteMaxCount) {
+			if len(batch) == 1 {
+				singles = append(singles, batch[0])
+				continue
+			}
+
+			err := s.bulkDelete(ctx, req, channelID, batch)
+			if err != nil {
+				err = fmt.Errorf("bulk delete: %w", err)
+			}
+
+			for _, message := range batch {
+				results = append(results, DeleteResult{Message: message, Err: err})
+			}
 		}
+	}
+
+	for _, message := range singles {
+		results = append(results, DeleteResult{Message: message, Err: s.DeleteMessage(ctx, req, message)})
+	}
+
+	return results
+}
+
+func (s Service) bulkDelete(ctx context.Context, req request.Request, channelID string, messages []Message) error {
+	payload := map[string][]string{
+		"messages": make([]string, len(messages)),
+	}
+
+	for i, message := range messages {
+		payload["messages"][i] = message.ID
+	}
 
+	if err := discard(ctx, req.Path("/channels/%s/messages/bulk-delete", channelID).Method(http.MethodPost), payload); err != nil {
 		return fmt.Errorf("delete: %w", err)
 	}
 
-	if err := request.DiscardBody(resp.Body); err != nil {
-		return fmt.Errorf("discard: %w", err)
+	for _, message := range messages {
+		s.invalidateCustomIDs(ctx, message.Components, nil)
 	}
 
 	return nil
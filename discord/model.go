@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 )
 
 const customIDMaxLen = 100
@@ -29,64 +30,207 @@ const (
 type componentType uint
 
 const (
-	ActionRowType componentType = 1
-	buttonType    componentType = 2
+	ActionRowType         componentType = 1
+	buttonType            componentType = 2
+	stringSelectType      componentType = 3
+	userSelectType        componentType = 5
+	roleSelectType        componentType = 6
+	mentionableSelectType componentType = 7
+	channelSelectType     componentType = 8
+	SectionType           componentType = 9
+	TextDisplayType       componentType = 10
+	ThumbnailType         componentType = 11
+	MediaGalleryType      componentType = 12
+	FileType              componentType = 13
+	SeparatorType         componentType = 14
+	ContainerType         componentType = 17
 )
 
-type buttonStyle uint
+type ButtonStyle uint
 
 const (
-	PrimaryButton   buttonStyle = 1
-	SecondaryButton buttonStyle = 2
-	DangerButton    buttonStyle = 4
+	PrimaryButton   ButtonStyle = 1
+	SecondaryButton ButtonStyle = 2
+	SuccessButton   ButtonStyle = 3
+	DangerButton    ButtonStyle = 4
+	LinkButton      ButtonStyle = 5
+	PremiumButton   ButtonStyle = 6
 )
 
 const (
-	EphemeralMessage int = 1 << 6
+	CrosspostedMessage           int = 1 << 0
+	IsCrosspostMessage           int = 1 << 1
+	SuppressEmbedsMessage        int = 1 << 2
+	HasThreadMessage             int = 1 << 5
+	EphemeralMessage             int = 1 << 6
+	SuppressNotificationsMessage int = 1 << 12
+	ComponentsV2Message          int = 1 << 15
+)
+
+type InteractionContextType uint
+
+const (
+	GuildContext          InteractionContextType = 0
+	BotDMContext          InteractionContextType = 1
+	PrivateChannelContext InteractionContextType = 2
 )
 
 type InteractionRequest struct {
-	Member        Member `json:"member"`
-	ID            string `json:"id"`
-	GuildID       string `json:"guild_id"`
-	Token         string `json:"token"`
-	ApplicationID string `json:"application_id"`
+	User          *User         `json:"user,omitempty"`
+	Channel       *Channel      `json:"channel,omitempty"`
+	Member        Member        `json:"member"`
+	ID            string        `json:"id"`
+	GuildID       string        `json:"guild_id"`
+	ChannelID     string        `json:"channel_id"`
+	Token         string        `json:"token"`
+	ApplicationID string        `json:"application_id"`
+	Entitlements  []Entitlement `json:"entitlements"`
 	Message       struct {
 		Interaction struct {
 			Name string `json:"name"`
 		} `json:"interaction"`
+		Components []Component `json:"components"`
 	} `json:"message"`
 	Data struct {
+		Resolved Resolved        `json:"resolved"`
 		Name     string          `json:"name"`
 		CustomID string          `json:"custom_id"`
+		TargetID string          `json:"target_id"`
 		Options  []CommandOption `json:"options"`
+		Type     CommandType     `json:"type"`
 	} `json:"data"`
-	Type interactionType `json:"type"`
+	AppPermissions Permissions            `json:"app_permissions"`
+	Type           interactionType        `json:"type"`
+	Context        InteractionContextType `json:"context"`
+}
+
+// Invoker returns the user who triggered the interaction, in a guild or in a DM
+func (i InteractionRequest) Invoker() User {
+	if len(i.Member.User.ID) != 0 {
+		return i.Member.User
+	}
+
+	if i.User != nil {
+		return *i.User
+	}
+
+	return User{}
+}
+
+// TargetUser returns the user targeted by a user command
+func (i InteractionRequest) TargetUser() (User, bool) {
+	if i.Data.Type != UserCommand {
+		return User{}, false
+	}
+
+	return i.Data.Resolved.User(i.Data.TargetID)
+}
+
+// TargetMember returns the member targeted by a user command, only available in a guild
+func (i InteractionRequest) TargetMember() (Member, bool) {
+	if i.Data.Type != UserCommand {
+		return Member{}, false
+	}
+
+	return i.Data.Resolved.Member(i.Data.TargetID)
+}
+
+// TargetMessage returns the message targeted by a message command
+func (i InteractionRequest) TargetMessage() (Message, bool) {
+	if i.Data.Type != MessageCommand {
+		return Message{}, false
+	}
+
+	return i.Data.Resolved.Message(i.Data.TargetID)
+}
+
+// Resolved contains the objects referenced by the options of an interaction, by ID
+type Resolved struct {
+	Users       map[string]User              `json:"users,omitempty"`
+	Members     map[string]Member            `json:"members,omitempty"`
+	Roles       map[string]Role              `json:"roles,omitempty"`
+	Channels    map[string]Channel           `json:"channels,omitempty"`
+	Messages    map[string]Message           `json:"messages,omitempty"`
+	Attachments map[string]MessageAttachment `json:"attachments,omitempty"`
+}
+
+func (r Resolved) User(id string) (User, bool) {
+	user, ok := r.Users[id]
+	return user, ok
+}
+
+// Member returns the resolved member, completed with its user
+func (r Resolved) Member(id string) (Member, bool) {
+	member, ok := r.Members[id]
+	if ok {
+		member.User = r.Users[id]
+	}
+
+	return member, ok
+}
+
+func (r Resolved) Role(id string) (Role, bool) {
+	role, ok := r.Roles[id]
+	return role, ok
+}
+
+func (r Resolved) Channel(id string) (Channel, bool) {
+	channel, ok := r.Channels[id]
+	return channel, ok
+}
+
+func (r Resolved) Message(id string) (Message, bool) {
+	message, ok := r.Messages[id]
+	return message, ok
+}
+
+func (r Resolved) Attachment(id string) (MessageAttachment, bool) {
+	attachment, ok := r.Attachments[id]
+	return attachment, ok
 }
 
 type Member struct {
-	User struct {
-		ID       string `json:"id,omitempty"`
-		Username string `json:"username,omitempty"`
-	} `json:"user"`
+	JoinedAt    time.Time   `json:"joined_at"`
+	User        User        `json:"user"`
+	Nick        string      `json:"nick,omitempty"`
+	Roles       []string    `json:"roles,omitempty"`
+	Permissions Permissions `json:"permissions,omitempty"`
+}
+
+// DisplayName returns the name of the member as displayed in the guild
+func (m Member) DisplayName() string {
+	if len(m.Nick) != 0 {
+		return m.Nick
+	}
+
+	if len(m.User.GlobalName) != 0 {
+		return m.User.GlobalName
+	}
+
+	return m.User.Username
+}
+
+// Can checks if the member has all the given permissions, as computed by Discord for the interaction's channel
+func (m Member) Can(permissions Permissions) bool {
+	return m.Permissions.Has(AdministratorPermission) || m.Permissions.Has(permissions)
 }
 
 type InteractionDataResponse struct {
-	Content         string          `json:"content,omitempty"`
-	AllowedMentions AllowedMentions `json:"allowed_mentions"`
-	Embeds          []Embed         `json:"embeds"`      // no `omitempty` to pass empty array when cleared
-	Components      []Component     `json:"components"`  // no `omitempty` to pass empty array when cleared
-	Attachments     []Attachment    `json:"attachments"` // no `omitempty` to pass empty array when cleared
-	Flags           int             `json:"flags"`
+	MessageReference *MessageReference `json:"message_reference,omitempty"`
+	Poll             *Poll             `json:"poll,omitempty"`
+	Content          string            `json:"content,omitempty"`
+	AllowedMentions  AllowedMentions   `json:"allowed_mentions"`
+	Embeds           []Embed           `json:"embeds"`      // no `omitempty` to pass empty array when cleared
+	Components       []Component       `json:"components"`  // no `omitempty` to pass empty array when cleared
+	Attachments      []Attachment      `json:"attachments"` // no `omitempty` to pass empty array when cleared
+	Flags            int               `json:"flags"`
 }
 
 // NewDataResponse create a data response
 func NewDataResponse(content string) InteractionDataResponse {
 	return InteractionDataResponse{
-		Content: content,
-		AllowedMentions: AllowedMentions{
-			Parse: []string{},
-		},
+		Content:         content,
+		AllowedMentions: NoMentions(),
 	}
 }
 
@@ -101,6 +245,33 @@ func (d InteractionDataResponse) AddEmbed(embed Embed) InteractionDataResponse {
 	return d
 }
 
+// AddComponent add given component to response
+func (d InteractionDataResponse) AddComponent(component Component) InteractionDataResponse {
+	if d.Components == nil {
+		d.Components = []Component{component}
+	} else {
+		d.Components = append(d.Components, component)
+	}
+
+	return d
+}
+
+// AddAttachment add given file to response
+func (d InteractionDataResponse) AddAttachment(filename, filepath string, size int64) InteractionDataResponse {
+	d.Attachments = append(d.Attachments, newAttachment(len(d.Attachments), size, filename, filepath, d.Flags&EphemeralMessage != 0))
+	return d
+}
+
+// ReplyTo set the reference to the message being replied
+func (d InteractionDataResponse) ReplyTo(message Message) InteractionDataResponse {
+	d.MessageReference = &MessageReference{
+		MessageID: message.ID,
+		ChannelID: message.ChannelID,
+	}
+
+	return d
+}
+
 type InteractionResponse struct {
 	Data InteractionDataResponse `json:"data"`
 	Type InteractionCallbackType `json:"type,omitempty"`
@@ -114,7 +285,7 @@ func NewResponse(iType InteractionCallbackType, content string) InteractionRespo
 }
 
 func (i InteractionResponse) Ephemeral() InteractionResponse {
-	i.Data.Flags = EphemeralMessage
+	i.Data.Flags |= EphemeralMessage
 	return i
 }
 
@@ -124,17 +295,12 @@ func (i InteractionResponse) AddEmbed(embed Embed) InteractionResponse {
 }
 
 func (i InteractionResponse) AddComponent(component Component) InteractionResponse {
-	if i.Data.Components == nil {
-		i.Data.Components = []Component{component}
-	} else {
-		i.Data.Components = append(i.Data.Components, component)
-	}
-
+	i.Data = i.Data.AddComponent(component)
 	return i
 }
 
 func (i InteractionResponse) AddAttachment(filename, filepath string, size int64) InteractionResponse {
-	i.Data.Attachments = append(i.Data.Attachments, newAttachment(len(i.Data.Attachments), size, filename, filepath, i.Data.Flags&EphemeralMessage != 0))
+	i.Data = i.Data.AddAttachment(filename, filepath, size)
 	return i
 }
 
@@ -188,12 +354,57 @@ func NewReplace(content string) InteractionResponse {
 	}
 }
 
+const (
+	UsersMention    = "users"
+	RolesMention    = "roles"
+	EveryoneMention = "everyone"
+)
+
 type AllowedMentions struct {
-	Parse []string `json:"parse"`
+	Parse       []string `json:"parse"`
+	Users       []string `json:"users,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	RepliedUser bool     `json:"replied_user,omitempty"`
+}
+
+// NoMentions pings nobody, whatever the content
+func NoMentions() AllowedMentions {
+	return AllowedMentions{
+		Parse: []string{},
+	}
+}
+
+// UserMentionsOnly pings mentioned users, but neither roles nor `@everyone`
+func UserMentionsOnly() AllowedMentions {
+	return AllowedMentions{
+		Parse: []string{UsersMention},
+	}
+}
+
+// ExplicitMentions pings only the given users and roles, if mentioned in the content
+func ExplicitMentions(users, roles []string) AllowedMentions {
+	return AllowedMentions{
+		Parse: []string{},
+		Users: users,
+		Roles: roles,
+	}
+}
+
+func (d InteractionDataResponse) WithAllowedMentions(allowedMentions AllowedMentions) InteractionDataResponse {
+	d.AllowedMentions = allowedMentions
+	return d
+}
+
+func (i InteractionResponse) WithAllowedMentions(allowedMentions AllowedMentions) InteractionResponse {
+	i.Data = i.Data.WithAllowedMentions(allowedMentions)
+	return i
 }
 
 type Image struct {
-	URL string `json:"url"`
+	URL      string `json:"url"`
+	ProxyURL string `json:"proxy_url,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Width    int    `json:"width,omitempty"`
 }
 
 func NewImage(url string) *Image {
@@ -203,8 +414,10 @@ func NewImage(url string) *Image {
 }
 
 type Author struct {
-	Name string `json:"name"`
-	URL  string `json:"url,omitempty"`
+	Name         string `json:"name"`
+	URL          string `json:"url,omitempty"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ProxyIconURL string `json:"proxy_icon_url,omitempty"`
 }
 
 func NewAuthor(name, url string) *Author {
@@ -214,15 +427,31 @@ func NewAuthor(name, url string) *Author {
 	}
 }
 
+type Footer struct {
+	Text         string `json:"text"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ProxyIconURL string `json:"proxy_icon_url,omitempty"`
+}
+
+type Provider struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
 type Embed struct {
-	Thumbnail   *Image  `json:"thumbnail,omitempty"`
-	Image       *Image  `json:"image,omitempty"`
-	Author      *Author `json:"author,omitempty"`
-	Title       string  `json:"title,omitempty"`
-	Description string  `json:"description,omitempty"`
-	URL         string  `json:"url,omitempty"`
-	Fields      []Field `json:"fields,omitempty"`
-	Color       int     `json:"color,omitempty"`
+	Timestamp   *time.Time `json:"timestamp,omitempty"`
+	Thumbnail   *Image     `json:"thumbnail,omitempty"`
+	Image       *Image     `json:"image,omitempty"`
+	Video       *Image     `json:"video,omitempty"`
+	Author      *Author    `json:"author,omitempty"`
+	Footer      *Footer    `json:"footer,omitempty"`
+	Provider    *Provider  `json:"provider,omitempty"`
+	Type        string     `json:"type,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Fields      []Field    `json:"fields,omitempty"`
+	Color       int        `json:"color,omitempty"`
 }
 
 func (e Embed) SetColor(color int) Embed {
@@ -245,14 +474,28 @@ func NewField(name, value string) Field {
 }
 
 type Component struct {
-	Label      string        `json:"label,omitempty"`
-	CustomID   string        `json:"custom_id,omitempty"`
-	Components []Component   `json:"components,omitempty"`
-	Type       componentType `json:"type,omitempty"`
-	Style      buttonStyle   `json:"style,omitempty"`
+	Accessory   *Component         `json:"accessory,omitempty"`
+	Media       *UnfurledMedia     `json:"media,omitempty"`
+	File        *UnfurledMedia     `json:"file,omitempty"`
+	Divider     *bool              `json:"divider,omitempty"`
+	AccentColor *int               `json:"accent_color,omitempty"`
+	Emoji       *Emoji             `json:"emoji,omitempty"`
+	Label       string             `json:"label,omitempty"`
+	CustomID    string             `json:"custom_id,omitempty"`
+	URL         string             `json:"url,omitempty"`
+	SKUID       string             `json:"sku_id,omitempty"`
+	Content     string             `json:"content,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Components  []Component        `json:"components,omitempty"`
+	Items       []MediaGalleryItem `json:"items,omitempty"`
+	Type        componentType      `json:"type,omitempty"`
+	Style       ButtonStyle        `json:"style,omitempty"`
+	Spacing     SeparatorSpacing   `json:"spacing,omitempty"`
+	Spoiler     bool               `json:"spoiler,omitempty"`
+	Disabled    bool               `json:"disabled,omitempty"`
 }
 
-func NewButton(style buttonStyle, label, customID string) Component {
+func NewButton(style ButtonStyle, label, customID string) Component {
 	if len(customID) > customIDMaxLen {
 		slog.LogAttrs(context.Background(), slog.LevelWarn, "`custom_id` exceeds max characters", slog.Int("max", customIDMaxLen))
 	}
@@ -265,6 +508,73 @@ func NewButton(style buttonStyle, label, customID string) Component {
 	}
 }
 
+// NewLinkButton creates a button opening the given URL, without interaction
+func NewLinkButton(label, url string) Component {
+	return Component{
+		Type:  buttonType,
+		Style: LinkButton,
+		Label: label,
+		URL:   url,
+	}
+}
+
+// NewPremiumButton creates a button prompting to purchase the given SKU, label and emoji are set by Discord
+func NewPremiumButton(skuID string) Component {
+	return Component{
+		Type:  buttonType,
+		Style: PremiumButton,
+		SKUID: skuID,
+	}
+}
+
+func (c Component) WithEmoji(emoji Emoji) Component {
+	c.Emoji = &emoji
+	return c
+}
+
+func (c Component) Disable() Component {
+	c.Disabled = true
+	return c
+}
+
+// DisableComponents returns a copy of the components with every button and select menu disabled, recursively
+func DisableComponents(components []Component) []Component {
+	if components == nil {
+		return nil
+	}
+
+	output := make([]Component, len(components))
+
+	for i, component := range components {
+		if isInteractive(component) {
+			component.Disabled = true
+		}
+
+		if component.Accessory != nil {
+			accessory := DisableComponents([]Component{*component.Accessory})[0]
+			component.Accessory = &accessory
+		}
+
+		component.Components = DisableComponents(component.Components)
+		output[i] = component
+	}
+
+	return output
+}
+
+func (i InteractionResponse) DisableComponents() InteractionResponse {
+	i.Data.Components = DisableComponents(i.Data.Components)
+	return i
+}
+
+// NewDisabledReplace replaces the message of the interaction by the given content, keeping its components but disabled
+func NewDisabledReplace(message InteractionRequest, content string) InteractionResponse {
+	response := NewReplace(content)
+	response.Data.Components = DisableComponents(message.Message.Components)
+
+	return response
+}
+
 type Attachment struct {
 	Filename  string `json:"filename"`
 	filepath  string
@@ -283,11 +593,38 @@ func newAttachment(id int, size int64, filename, filepath string, ephemeral bool
 	}
 }
 
+type CommandType uint
+
+const (
+	ChatInputCommand CommandType = 1
+	UserCommand      CommandType = 2
+	MessageCommand   CommandType = 3
+)
+
 type Command struct {
 	Name        string          `json:"name,omitempty"`
 	Description string          `json:"description,omitempty"`
 	Options     []CommandOption `json:"options,omitempty"`
 	Guilds      []string        `json:"-"`
+	Type        CommandType     `json:"type,omitempty"`
+}
+
+// NewUserCommand creates a command displayed in the context menu of a user
+func NewUserCommand(name string, guilds ...string) Command {
+	return Command{
+		Type:   UserCommand,
+		Name:   name,
+		Guilds: guilds,
+	}
+}
+
+// NewMessageCommand creates a command displayed in the context menu of a message
+func NewMessageCommand(name string, guilds ...string) Command {
+	return Command{
+		Type:   MessageCommand,
+		Name:   name,
+		Guilds: guilds,
+	}
 }
 
 type CommandOption struct {
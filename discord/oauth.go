@@ -1,32 +1,78 @@
 package discord
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/ViBiOh/httputils/v4/pkg/httperror"
+	"github.com/ViBiOh/httputils/v4/pkg/httpjson"
 	"github.com/ViBiOh/httputils/v4/pkg/request"
 )
 
+type OAuthToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// HasScope checks if the token has been granted the given scope
+func (o OAuthToken) HasScope(scope string) bool {
+	return slices.Contains(strings.Fields(o.Scope), scope)
+}
+
+// Client returns a request authenticated on behalf of the user
+func (o OAuthToken) Client() request.Request {
+	return discordRequest.Header("Authorization", fmt.Sprintf("%s %s", o.TokenType, o.AccessToken))
+}
+
+// handleOauth exchanges the authorization code. When the `state` comes from handleVerify, it also updates the user's role connection.
 func (s Service) handleOauth(w http.ResponseWriter, r *http.Request) {
-	params := url.Values{}
-	params.Set("code", r.URL.Query().Get("code"))
-	params.Set("client_id", s.clientID)
-	params.Set("client_secret", s.clientSecret)
-	params.Set("grant_type", "authorization_code")
-	params.Set("redirect_uri", s.website)
+	redirectURI := s.website
+
+	linking := s.roleConnector != nil && r.URL.Query().Has("state")
+	if linking {
+		if err := checkOAuthState(w, r); err != nil {
+			httperror.Forbidden(r.Context(), w, fmt.Errorf("check oauth state: %w", err))
+			return
+		}
+
+		redirectURI = s.roleCallbackURL
+	}
 
-	resp, err := discordRequest.Path("/oauth2/token").Method(http.MethodPost).Form(r.Context(), params)
+	token, err := s.exchangeCode(r.Context(), r.URL.Query().Get("code"), redirectURI)
 	if err != nil {
 		httperror.InternalServerError(r.Context(), w, fmt.Errorf("confirm oauth request: %w", err))
 		return
 	}
 
-	if err := request.DiscardBody(resp.Body); err != nil {
-		httperror.InternalServerError(r.Context(), w, fmt.Errorf("discard body: %w", err))
-		return
+	if linking && token.HasScope(roleConnectionScope) {
+		if err := s.connectRoles(r.Context(), token); err != nil {
+			httperror.InternalServerError(r.Context(), w, fmt.Errorf("connect roles: %w", err))
+			return
+		}
 	}
 
 	http.Redirect(w, r, s.website, http.StatusFound)
 }
+
+func (s Service) exchangeCode(ctx context.Context, code, redirectURI string) (OAuthToken, error) {
+	params := url.Values{}
+	params.Set("code", code)
+	params.Set("client_id", s.clientID)
+	params.Set("client_secret", s.clientSecret)
+	params.Set("grant_type", "authorization_code")
+	params.Set("redirect_uri", redirectURI)
+
+	resp, err := discordRequest.Path("/oauth2/token").Method(http.MethodPost).Form(ctx, params)
+	if err != nil {
+		return OAuthToken{}, err
+	}
+
+	return httpjson.Read[OAuthToken](resp)
+}
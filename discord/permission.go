@@ -0,0 +1,287 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Permissions uint64
+
+const (
+	CreateInstantInvitePermission              Permissions = 1 << 0
+	KickMembersPermission                      Permissions = 1 << 1
+	BanMembersPermission                       Permissions = 1 << 2
+	AdministratorPermission                    Permissions = 1 << 3
+	ManageChannelsPermission                   Permissions = 1 << 4
+	ManageGuildPermission                      Permissions = 1 << 5
+	AddReactionsPermission                     Permissions = 1 << 6
+	ViewAuditLogPermission                     Permissions = 1 << 7
+	PrioritySpeakerPermission                  Permissions = 1 << 8
+	StreamPermission                           Permissions = 1 << 9
+	ViewChannelPermission                      Permissions = 1 << 10
+	SendMessagesPermission                     Permissions = 1 << 11
+	SendTTSMessagesPermission                  Permissions = 1 << 12
+	ManageMessagesPermission                   Permissions = 1 << 13
+	EmbedLinksPermission                       Permissions = 1 << 14
+	AttachFilesPermission                      Permissions = 1 << 15
+	ReadMessageHistoryPermission               Permissions = 1 << 16
+	MentionEveryonePermission                  Permissions = 1 << 17
+	UseExternalEmojisPermission                Permissions = 1 << 18
+	ViewGuildInsightsPermission                Permissions = 1 << 19
+	ConnectPermission                          Permissions = 1 << 20
+	SpeakPermission                            Permissions = 1 << 21
+	MuteMembersPermission                      Permissions = 1 << 22
+	DeafenMembersPermission                    Permissions = 1 << 23
+	MoveMembersPermission                      Permissions = 1 << 24
+	UseVADPermission                           Permissions = 1 << 25
+	ChangeNicknamePermission                   Permissions = 1 << 26
+	ManageNicknamesPermission                  Permissions = 1 << 27
+	ManageRolesPermission                      Permissions = 1 << 28
+	ManageWebhooksPermission                   Permissions = 1 << 29
+	ManageGuildExpressionsPermission           Permissions = 1 << 30
+	UseApplicationCommandsPermission           Permissions = 1 << 31
+	RequestToSpeakPermission                   Permissions = 1 << 32
+	ManageEventsPermission                     Permissions = 1 << 33
+	ManageThreadsPermission                    Permissions = 1 << 34
+	CreatePublicThreadsPermission              Permissions = 1 << 35
+	CreatePrivateThreadsPermission             Permissions = 1 << 36
+	UseExternalStickersPermission              Permissions = 1 << 37
+	SendMessagesInThreadsPermission            Permissions = 1 << 38
+	UseEmbeddedActivitiesPermission            Permissions = 1 << 39
+	ModerateMembersPermission                  Permissions = 1 << 40
+	ViewCreatorMonetizationAnalyticsPermission Permissions = 1 << 41
+	UseSoundboardPermission                    Permissions = 1 << 42
+	CreateGuildExpressionsPermission           Permissions = 1 << 43
+	CreateEventsPermission                     Permissions = 1 << 44
+	UseExternalSoundsPermission                Permissions = 1 << 45
+	SendVoiceMessagesPermission                Permissions = 1 << 46
+	SendPollsPermission                        Permissions = 1 << 49
+	UseExternalAppsPermission                  Permissions = 1 << 50
+
+	// AllPermissions holds every defined permission
+	AllPermissions = CreateInstantInvitePermission | KickMembersPermission | BanMembersPermission |
+		AdministratorPermission | ManageChannelsPermission | ManageGuildPermission | AddReactionsPermission |
+		ViewAuditLogPermission | PrioritySpeakerPermission | StreamPermission | ViewChannelPermission |
+		SendMessagesPermission | SendTTSMessagesPermission | ManageMessagesPermission | EmbedLinksPermission |
+		AttachFilesPermission | ReadMessageHistoryPermission | MentionEveryonePermission |
+		UseExternalEmojisPermission | ViewGuildInsightsPermission | ConnectPermission | SpeakPermission |
+		MuteMembersPermission | DeafenMembersPermission | MoveMembersPermission | UseVADPermission |
+		ChangeNicknamePermission | ManageNicknamesPermission | ManageRolesPermission | ManageWebhooksPermission |
+		ManageGuildExpressionsPermission | UseApplicationCommandsPermission | RequestToSpeakPermission |
+		ManageEventsPermission | ManageThreadsPermission | CreatePublicThreadsPermission |
+		CreatePrivateThreadsPermission | UseExternalStickersPermission | SendMessagesInThreadsPermission |
+		UseEmbeddedActivitiesPermission | ModerateMembersPermission | ViewCreatorMonetizationAnalyticsPermission |
+		UseSoundboardPermission | CreateGuildExpressionsPermission | CreateEventsPermission |
+		UseExternalSoundsPermission | SendVoiceMessagesPermission | SendPollsPermission | UseExternalAppsPermission
+)
+
+type permissionName struct {
+	name       string
+	permission Permissions
+}
+
+var permissionNames = []permissionName{
+	{"CREATE_INSTANT_INVITE", CreateInstantInvitePermission},
+	{"KICK_MEMBERS", KickMembersPermission},
+	{"BAN_MEMBERS", BanMembersPermission},
+	{"ADMINISTRATOR", AdministratorPermission},
+	{"MANAGE_CHANNELS", ManageChannelsPermission},
+	{"MANAGE_GUILD", ManageGuildPermission},
+	{"ADD_REACTIONS", AddReactionsPermission},
+	{"VIEW_AUDIT_LOG", ViewAuditLogPermission},
+	{"PRIORITY_SPEAKER", PrioritySpeakerPermission},
+	{"STREAM", StreamPermission},
+	{"VIEW_CHANNEL", ViewChannelPermission},
+	{"SEND_MESSAGES", SendMessagesPermission},
+	{"SEND_TTS_MESSAGES", SendTTSMessagesPermission},
+	{"MANAGE_MESSAGES", ManageMessagesPermission},
+	{"EMBED_LINKS", EmbedLinksPermission},
+	{"ATTACH_FILES", AttachFilesPermission},
+	{"READ_MESSAGE_HISTORY", ReadMessageHistoryPermission},
+	{"MENTION_EVERYONE", MentionEveryonePermission},
+	{"USE_EXTERNAL_EMOJIS", UseExternalEmojisPermission},
+	{"VIEW_GUILD_INSIGHTS", ViewGuildInsightsPermission},
+	{"CONNECT", ConnectPermission},
+	{"SPEAK", SpeakPermission},
+	{"MUTE_MEMBERS", MuteMembersPermission},
+	{"DEAFEN_MEMBERS", DeafenMembersPermission},
+	{"MOVE_MEMBERS", MoveMembersPermission},
+	{"USE_VAD", UseVADPermission},
+	{"CHANGE_NICKNAME", ChangeNicknamePermission},
+	{"MANAGE_NICKNAMES", ManageNicknamesPermission},
+	{"MANAGE_ROLES", ManageRolesPermission},
+	{"MANAGE_WEBHOOKS", ManageWebhooksPermission},
+	{"MANAGE_GUILD_EXPRESSIONS", ManageGuildExpressionsPermission},
+	{"USE_APPLICATION_COMMANDS", UseApplicationCommandsPermission},
+	{"REQUEST_TO_SPEAK", RequestToSpeakPermission},
+	{"MANAGE_EVENTS", ManageEventsPermission},
+	{"MANAGE_THREADS", ManageThreadsPermission},
+	{"CREATE_PUBLIC_THREADS", CreatePublicThreadsPermission},
+	{"CREATE_PRIVATE_THREADS", CreatePrivateThreadsPermission},
+	{"USE_EXTERNAL_STICKERS", UseExternalStickersPermission},
+	{"SEND_MESSAGES_IN_THREADS", SendMessagesInThreadsPermission},
+	{"USE_EMBEDDED_ACTIVITIES", UseEmbeddedActivitiesPermission},
+	{"MODERATE_MEMBERS", ModerateMembersPermission},
+	{"VIEW_CREATOR_MONETIZATION_ANALYTICS", ViewCreatorMonetizationAnalyticsPermission},
+	{"USE_SOUNDBOARD", UseSoundboardPermission},
+	{"CREATE_GUILD_EXPRESSIONS", CreateGuildExpressionsPermission},
+	{"CREATE_EVENTS", CreateEventsPermission},
+	{"USE_EXTERNAL_SOUNDS", UseExternalSoundsPermission},
+	{"SEND_VOICE_MESSAGES", SendVoiceMessagesPermission},
+	{"SEND_POLLS", SendPollsPermission},
+	{"USE_EXTERNAL_APPS", UseExternalAppsPermission},
+}
+
+// ParsePermissions parses permissions given as an integer, or as Discord names (e.g. `SEND_MESSAGES`) separated by `|` or `,`
+func ParsePermissions(value string) (Permissions, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	if parsed, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return Permissions(parsed), nil
+	}
+
+	var output Permissions
+
+	for name := range strings.FieldsFuncSeq(value, func(r rune) bool { return r == '|' || r == ',' }) {
+		name = strings.ToUpper(strings.TrimSpace(name))
+
+		index := slices.IndexFunc(permissionNames, func(item permissionName) bool { return item.name == name })
+		if index == -1 {
+			return 0, fmt.Errorf("unknown permission `%s`", name)
+		}
+
+		output |= permissionNames[index].permission
+	}
+
+	return output, nil
+}
+
+// Has checks if all the given permissions are set
+func (p Permissions) Has(permissions Permissions) bool {
+	return p&permissions == permissions
+}
+
+// String returns the Discord names of the permissions, separated by `|`
+func (p Permissions) String() string {
+	if p == 0 {
+		return "NONE"
+	}
+
+	var names []string
+
+	for _, item := range permissionNames {
+		if p.Has(item.permission) {
+			names = append(names, item.name)
+			p &^= item.permission
+		}
+	}
+
+	if p != 0 {
+		names = append(names, strconv.FormatUint(uint64(p), 10))
+	}
+
+	return strings.Join(names, "|")
+}
+
+// MarshalJSON encodes permissions as a string, like Discord does
+func (p Permissions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatUint(uint64(p), 10))
+}
+
+// UnmarshalJSON decodes permissions from a string or a number
+func (p *Permissions) UnmarshalJSON(data []byte) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	switch value := raw.(type) {
+	case nil:
+		*p = 0
+
+	case string:
+		if len(value) == 0 {
+			*p = 0
+			return nil
+		}
+
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse: %w", err)
+		}
+
+		*p = Permissions(parsed)
+
+	case float64:
+		*p = Permissions(value)
+
+	default:
+		return fmt.Errorf("invalid permissions type `%T`", raw)
+	}
+
+	return nil
+}
+
+type OverwriteType uint
+
+const (
+	RoleOverwrite   OverwriteType = 0
+	MemberOverwrite OverwriteType = 1
+)
+
+type PermissionOverwrite struct {
+	ID    string        `json:"id"`
+	Type  OverwriteType `json:"type"`
+	Allow Permissions   `json:"allow"`
+	Deny  Permissions   `json:"deny"`
+}
+
+// ComputePermissions computes the permissions of a member in a channel, applying its overwrites. Roles must contain the guild's `@everyone` role.
+func ComputePermissions(guild Guild, roles []Role, member Member, channel Channel) Permissions {
+	if len(guild.OwnerID) != 0 && member.User.ID == guild.OwnerID {
+		return AllPermissions
+	}
+
+	var permissions Permissions
+
+	for _, role := range roles {
+		if role.ID == guild.ID || slices.Contains(member.Roles, role.ID) {
+			permissions |= role.Permissions
+		}
+	}
+
+	if permissions.Has(AdministratorPermission) {
+		return AllPermissions
+	}
+
+	var allow, deny Permissions
+
+	for _, overwrite := range channel.PermissionOverwrites {
+		if overwrite.Type == RoleOverwrite && overwrite.ID == guild.ID {
+			permissions = permissions&^overwrite.Deny | overwrite.Allow
+		}
+	}
+
+	for _, overwrite := range channel.PermissionOverwrites {
+		if overwrite.Type == RoleOverwrite && slices.Contains(member.Roles, overwrite.ID) {
+			allow |= overwrite.Allow
+			deny |= overwrite.Deny
+		}
+	}
+
+	permissions = permissions&^deny | allow
+
+	for _, overwrite := range channel.PermissionOverwrites {
+		if overwrite.Type == MemberOverwrite && overwrite.ID == member.User.ID {
+			permissions = permissions&^overwrite.Deny | overwrite.Allow
+		}
+	}
+
+	return permissions
+}
@@ -0,0 +1,93 @@
+package discord
+
+import "testing"
+
+func TestComputePermissions(t *testing.T) {
+	t.Parallel()
+
+	guild := Guild{ID: "guild", OwnerID: "owner"}
+
+	roles := []Role{
+		{ID: "guild", Permissions: ViewChannelPermission | SendMessagesPermission},
+		{ID: "moderator", Permissions: ManageMessagesPermission},
+		{ID: "admin", Permissions: AdministratorPermission},
+		{ID: "muted"},
+	}
+
+	cases := map[string]struct {
+		member  Member
+		channel Channel
+		want    Permissions
+	}{
+		"everyone": {
+			member: Member{User: User{ID: "user"}},
+			want:   ViewChannelPermission | SendMessagesPermission,
+		},
+		"roles": {
+			member: Member{User: User{ID: "user"}, Roles: []string{"moderator"}},
+			want:   ViewChannelPermission | SendMessagesPermission | ManageMessagesPermission,
+		},
+		"owner": {
+			member: Member{User: User{ID: "owner"}},
+			channel: Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: "guild", Type: RoleOverwrite, Deny: ViewChannelPermission},
+			}},
+			want: AllPermissions,
+		},
+		"administrator ignores overwrites": {
+			member: Member{User: User{ID: "user"}, Roles: []string{"admin"}},
+			channel: Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: "guild", Type: RoleOverwrite, Deny: ViewChannelPermission},
+				{ID: "user", Type: MemberOverwrite, Deny: SendMessagesPermission},
+			}},
+			want: AllPermissions,
+		},
+		"everyone overwrite": {
+			member: Member{User: User{ID: "user"}},
+			channel: Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: "guild", Type: RoleOverwrite, Allow: AddReactionsPermission, Deny: SendMessagesPermission},
+			}},
+			want: ViewChannelPermission | AddReactionsPermission,
+		},
+		"role overwrites allow over deny": {
+			member: Member{User: User{ID: "user"}, Roles: []string{"moderator", "muted"}},
+			channel: Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: "muted", Type: RoleOverwrite, Deny: SendMessagesPermission | AddReactionsPermission},
+				{ID: "moderator", Type: RoleOverwrite, Allow: SendMessagesPermission},
+			}},
+			want: ViewChannelPermission | SendMessagesPermission | ManageMessagesPermission,
+		},
+		"role overwrite of another role": {
+			member: Member{User: User{ID: "user"}},
+			channel: Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: "muted", Type: RoleOverwrite, Deny: SendMessagesPermission},
+			}},
+			want: ViewChannelPermission | SendMessagesPermission,
+		},
+		"member overwrite wins over roles": {
+			member: Member{User: User{ID: "user"}, Roles: []string{"moderator"}},
+			channel: Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: "moderator", Type: RoleOverwrite, Allow: AttachFilesPermission},
+				{ID: "user", Type: MemberOverwrite, Allow: EmbedLinksPermission, Deny: ManageMessagesPermission | AttachFilesPermission},
+			}},
+			want: ViewChannelPermission | SendMessagesPermission | EmbedLinksPermission,
+		},
+		"member overwrite of another member": {
+			member: Member{User: User{ID: "user"}},
+			channel: Channel{PermissionOverwrites: []PermissionOverwrite{
+				{ID: "other", Type: MemberOverwrite, Deny: ViewChannelPermission},
+			}},
+			want: ViewChannelPermission | SendMessagesPermission,
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ComputePermissions(guild, roles, testCase.member, testCase.channel); got != testCase.want {
+				t.Errorf("ComputePermissions() = %s, want %s", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+const (
+	pollVotersPageSize = 100
+	maxPollQuestion    = 300
+	maxPollAnswers     = 10
+	maxPollAnswerText  = 55
+	maxPollDuration    = 32 * 24
+)
+
+type PollMedia struct {
+	Emoji *Emoji `json:"emoji,omitempty"`
+	Text  string `json:"text,omitempty"`
+}
+
+type PollAnswer struct {
+	PollMedia PollMedia `json:"poll_media"`
+	AnswerID  int       `json:"answer_id,omitempty"`
+}
+
+type PollAnswerCount struct {
+	ID      int  `json:"id"`
+	Count   int  `json:"count"`
+	MeVoted bool `json:"me_voted"`
+}
+
+type PollResults struct {
+	AnswerCounts []PollAnswerCount `json:"answer_counts"`
+	IsFinalized  bool              `json:"is_finalized"`
+}
+
+type Poll struct {
+	Expiry           *time.Time   `json:"expiry,omitempty"`
+	Results          *PollResults `json:"results,omitempty"`
+	Question         PollMedia    `json:"question"`
+	Answers          []PollAnswer `json:"answers"`
+	Duration         int          `json:"duration,omitempty"`
+	LayoutType       int          `json:"layout_type,omitempty"`
+	AllowMultiselect bool         `json:"allow_multiselect"`
+}
+
+// NewPoll creates a poll open for the given number of hours
+func NewPoll(question string, hours int) Poll {
+	return Poll{
+		Question: PollMedia{Text: question},
+		Duration: hours,
+	}
+}
+
+func (p Poll) AddAnswer(text string, emoji *Emoji) Poll {
+	p.Answers = append(p.Answers, PollAnswer{
+		PollMedia: PollMedia{
+			Text:  text,
+			Emoji: emoji,
+		},
+	})
+
+	return p
+}
+
+func (p Poll) Multiselect() Poll {
+	p.AllowMultiselect = true
+	return p
+}
+
+// Votes returns the number of votes of the given answer, as last computed by Discord
+func (p Poll) Votes(answerID int) int {
+	if p.Results == nil {
+		return 0
+	}
+
+	for _, count := range p.Results.AnswerCounts {
+		if count.ID == answerID {
+			return count.Count
+		}
+	}
+
+	return 0
+}
+
+func (d InteractionDataResponse) SetPoll(poll Poll) InteractionDataResponse {
+	d.Poll = &poll
+	return d
+}
+
+func (i InteractionResponse) SetPoll(poll Poll) InteractionResponse {
+	i.Data = i.Data.SetPoll(poll)
+	return i
+}
+
+type pollVoters struct {
+	Users []User `json:"users"`
+}
+
+// PollVoters sends to the output every user that voted for the given answer
+func (s Service) PollVoters(ctx context.Context, req request.Request, message Message, answerID int, output chan<- User) error {
+	baseURL := fmt.Sprintf("/channels/%s/polls/%s/answers/%d", message.ChannelID, message.ID, answerID)
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(pollVotersPageSize))
+
+	for {
+		page, err := read[pollVoters](ctx, req.Path(baseURL+"?"+query.Encode()).Method(http.MethodGet), nil)
+		if err != nil {
+			return fmt.Errorf("list: %w", err)
+		}
+
+		for _, user := range page.Users {
+			output <- user
+		}
+
+		if len(page.Users) < pollVotersPageSize {
+			return nil
+		}
+
+		query.Set("after", page.Users[len(page.Users)-1].ID)
+	}
+}
+
+// EndPoll closes the poll of the message immediately
+func (s Service) EndPoll(ctx context.Context, req request.Request, message Message) (Message, error) {
+	output, err := read[Message](ctx, req.Path("/channels/%s/polls/%s/expire", message.ChannelID, message.ID).Method(http.MethodPost), nil)
+	if err != nil {
+		return output, fmt.Errorf("expire: %w", err)
+	}
+
+	return output, nil
+}
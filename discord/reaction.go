@@ -0,0 +1,226 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+const reactionsPageSize = 100
+
+// APIName returns the emoji in the format expected by the reactions endpoints
+func (e Emoji) APIName() string {
+	if len(e.ID) == 0 {
+		return url.PathEscape(e.Name)
+	}
+
+	return url.PathEscape(e.Name + ":" + e.ID)
+}
+
+func (e Emoji) equals(other Emoji) bool {
+	if len(e.ID) != 0 || len(other.ID) != 0 {
+		return e.ID == other.ID
+	}
+
+	return e.Name == other.Name
+}
+
+func (s Service) AddReaction(ctx context.Context, req request.Request, message Message, emoji Emoji) error {
+	if err := discard(ctx, req.Path("/channels/%s/messages/%s/reactions/%s/@me", message.ChannelID, message.ID, emoji.APIName()).Method(http.MethodPut), nil); err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) RemoveOwnReaction(ctx context.Context, req request.Request, message Message, emoji Emoji) error {
+	return s.RemoveUserReaction(ctx, req, message, emoji, "@me")
+}
+
+func (s Service) RemoveUserReaction(ctx context.Context, req request.Request, message Message, emoji Emoji, userID string) error {
+	if err := discard(ctx, req.Path("/channels/%s/messages/%s/reactions/%s/%s", message.ChannelID, message.ID, emoji.APIName(), userID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) ClearReactions(ctx context.Context, req request.Request, message Message) error {
+	if err := discard(ctx, req.Path("/channels/%s/messages/%s/reactions", message.ChannelID, message.ID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("clear: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) ClearEmojiReactions(ctx context.Context, req request.Request, message Message, emoji Emoji) error {
+	if err := discard(ctx, req.Path("/channels/%s/messages/%s/reactions/%s", message.ChannelID, message.ID, emoji.APIName()).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("clear: %w", err)
+	}
+
+	return nil
+}
+
+// Reactors sends to the output every user that reacted with the given emoji
+func (s Service) Reactors(ctx context.Context, req request.Request, message Message, emoji Emoji, output chan<- User) error {
+	baseURL := fmt.Sprintf("/channels/%s/messages/%s/reactions/%s", message.ChannelID, message.ID, emoji.APIName())
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(reactionsPageSize))
+
+	for {
+		users, err := read[[]User](ctx, req.Path(baseURL+"?"+query.Encode()).Method(http.MethodGet), nil)
+		if err != nil {
+			return fmt.Errorf("list: %w", err)
+		}
+
+		for _, user := range users {
+			output <- user
+		}
+
+		if len(users) < reactionsPageSize {
+			return nil
+		}
+
+		query.Set("after", users[len(users)-1].ID)
+	}
+}
+
+// ReactionEvent is the payload of `MESSAGE_REACTION_ADD` and `MESSAGE_REACTION_REMOVE` Gateway events
+type ReactionEvent struct {
+	UserID    string `json:"user_id"`
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id"`
+	GuildID   string `json:"guild_id"`
+	Emoji     Emoji  `json:"emoji"`
+	Removed   bool   `json:"-"`
+}
+
+type ReactionRole struct {
+	RoleID string
+	Emoji  Emoji
+}
+
+// ReactionRoles grants a role to users reacting with a given emoji on a message, and revokes it when the reaction is removed
+type ReactionRoles struct {
+	reactors map[string][]string
+	service  Service
+	guildID  string
+	message  Message
+	roles    []ReactionRole
+	mutex    sync.Mutex
+}
+
+func (s Service) NewReactionRoles(guildID string, message Message, roles ...ReactionRole) *ReactionRoles {
+	return &ReactionRoles{
+		service: s,
+		guildID: guildID,
+		message: message,
+		roles:   roles,
+	}
+}
+
+// Setup adds the bot's own reactions on the message so users only have to click on them
+func (r *ReactionRoles) Setup(ctx context.Context, req request.Request) error {
+	for _, role := range r.roles {
+		if err := r.service.AddReaction(ctx, req, r.message, role.Emoji); err != nil {
+			return fmt.Errorf("react with `%s`: %w", role.Emoji.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Handle applies the role change of a reaction event received from the Gateway
+func (r *ReactionRoles) Handle(ctx context.Context, req request.Request, event ReactionEvent) error {
+	if event.MessageID != r.message.ID {
+		return nil
+	}
+
+	index := slices.IndexFunc(r.roles, func(role ReactionRole) bool { return role.Emoji.equals(event.Emoji) })
+	if index == -1 {
+		return nil
+	}
+
+	if event.Removed {
+		return r.service.RemoveMemberRole(ctx, req, r.guildID, event.UserID, r.roles[index].RoleID)
+	}
+
+	return r.service.AddMemberRole(ctx, req, r.guildID, event.UserID, r.roles[index].RoleID)
+}
+
+// Poll lists the reactors of every emoji and applies role changes since the previous poll. The first poll only grants roles.
+func (r *ReactionRoles) Poll(ctx context.Context, req request.Request) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	firstPoll := r.reactors == nil
+	reactors := make(map[string][]string, len(r.roles))
+
+	for _, role := range r.roles {
+		current, err := r.listReactors(ctx, req, role.Emoji)
+		if err != nil {
+			return fmt.Errorf("list reactors of `%s`: %w", role.Emoji.Name, err)
+		}
+
+		reactors[role.Emoji.APIName()] = current
+		previous := r.reactors[role.Emoji.APIName()]
+
+		for _, userID := range current {
+			if slices.Contains(previous, userID) {
+				continue
+			}
+
+			if err := r.service.AddMemberRole(ctx, req, r.guildID, userID, role.RoleID); err != nil {
+				return fmt.Errorf("add role: %w", err)
+			}
+		}
+
+		if firstPoll {
+			continue
+		}
+
+		for _, userID := range previous {
+			if slices.Contains(current, userID) {
+				continue
+			}
+
+			if err := r.service.RemoveMemberRole(ctx, req, r.guildID, userID, role.RoleID); err != nil {
+				return fmt.Errorf("remove role: %w", err)
+			}
+		}
+	}
+
+	r.reactors = reactors
+
+	return nil
+}
+
+func (r *ReactionRoles) listReactors(ctx context.Context, req request.Request, emoji Emoji) ([]string, error) {
+	usersCh := make(chan User, reactionsPageSize)
+	done := make(chan struct{})
+
+	var output []string
+
+	go func() {
+		defer close(done)
+
+		for user := range usersCh {
+			if !user.Bot {
+				output = append(output, user.ID)
+			}
+		}
+	}()
+
+	err := r.service.Reactors(ctx, req, r.message, emoji, usersCh)
+	close(usersCh)
+	<-done
+
+	return output, err
+}
@@ -2,12 +2,13 @@ package discord
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"net/url"
 	"slices"
 	"time"
 
-	"github.com/ViBiOh/httputils/v4/pkg/hash"
 	"github.com/ViBiOh/httputils/v4/pkg/redis"
 )
 
@@ -15,21 +16,89 @@ func cacheKey(prefix, content string) string {
 	return fmt.Sprintf("%s:%s", prefix, content)
 }
 
+// RedisCustomIDStore stores `custom_id` contents in Redis, under the given prefix
+type RedisCustomIDStore struct {
+	redis   redis.Client
+	prefix  string
+	sliding time.Duration
+}
+
+// NewRedisCustomIDStore creates a Redis store. When sliding is positive, each load resets the entry's expiration to this duration.
+func NewRedisCustomIDStore(redisApp redis.Client, prefix string, sliding time.Duration) RedisCustomIDStore {
+	return RedisCustomIDStore{
+		redis:   redisApp,
+		prefix:  prefix,
+		sliding: sliding,
+	}
+}
+
+func (r RedisCustomIDStore) Save(ctx context.Context, key, content string, ttl time.Duration) error {
+	return r.redis.Store(ctx, cacheKey(r.prefix, key), content, ttl)
+}
+
+func (r RedisCustomIDStore) Load(ctx context.Context, key string) (string, error) {
+	content, err := r.redis.Load(ctx, cacheKey(r.prefix, key))
+	if err != nil {
+		return "", fmt.Errorf("load redis: %w", err)
+	}
+
+	if len(content) == 0 {
+		return "", ErrCustomIDExpired
+	}
+
+	if r.sliding > 0 {
+		if err := r.redis.Expire(ctx, r.sliding, cacheKey(r.prefix, key)); err != nil {
+			return "", fmt.Errorf("extend expiration: %w", err)
+		}
+	}
+
+	return string(content), nil
+}
+
+func (r RedisCustomIDStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	cacheKeys := make([]string, len(keys))
+	for i, key := range keys {
+		cacheKeys[i] = cacheKey(r.prefix, key)
+	}
+
+	return r.redis.Delete(ctx, cacheKeys...)
+}
+
 func SaveCustomID(ctx context.Context, redisApp redis.Client, prefix string, values url.Values) (string, error) {
-	content := values.Encode()
-	key := hash.String(content)
-	return key, redisApp.Store(ctx, cacheKey(prefix, key), content, time.Hour)
+	return SaveCustomIDWithTTL(ctx, redisApp, prefix, values, DefaultCustomIDTTL)
+}
+
+func SaveCustomIDWithTTL(ctx context.Context, redisApp redis.Client, prefix string, values url.Values, ttl time.Duration) (string, error) {
+	return storeCustomID(ctx, NewRedisCustomIDStore(redisApp, prefix, 0), values.Encode(), ttl)
+}
+
+// storeCustomID saves the content under a random key, so that invalidating one message's custom IDs doesn't expire identical ones of other messages
+func storeCustomID(ctx context.Context, store CustomIDStore, content string, ttl time.Duration) (string, error) {
+	key := rand.Text()
+	return key, store.Save(ctx, key, content, ttl)
 }
 
 func RestoreCustomID(ctx context.Context, redisApp redis.Client, prefix, customID string, statics []string) (url.Values, error) {
+	return restoreCustomID(ctx, NewRedisCustomIDStore(redisApp, prefix, 0), customID, statics)
+}
+
+func restoreCustomID(ctx context.Context, store CustomIDStore, customID string, statics []string) (url.Values, error) {
 	if slices.Contains(statics, customID) {
 		return url.ParseQuery(customID)
 	}
 
-	content, err := redisApp.Load(ctx, cacheKey(prefix, customID))
+	content, err := store.Load(ctx, customID)
 	if err != nil {
-		return nil, fmt.Errorf("load redis: %w", err)
+		if errors.Is(err, ErrCustomIDExpired) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("load: %w", err)
 	}
 
-	return url.ParseQuery(string(content))
+	return url.ParseQuery(content)
 }
@@ -0,0 +1,54 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+type Role struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Permissions Permissions `json:"permissions"`
+	Color       int         `json:"color"`
+	Position    int         `json:"position"`
+	Hoist       bool        `json:"hoist"`
+	Managed     bool        `json:"managed"`
+	Mentionable bool        `json:"mentionable"`
+}
+
+func (s Service) Roles(ctx context.Context, req request.Request, guildID string) ([]Role, error) {
+	roles, err := read[[]Role](ctx, req.Path("/guilds/%s/roles", guildID).Method(http.MethodGet), nil)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (s Service) Member(ctx context.Context, req request.Request, guildID, userID string) (Member, error) {
+	member, err := read[Member](ctx, req.Path("/guilds/%s/members/%s", guildID, userID).Method(http.MethodGet), nil)
+	if err != nil {
+		return member, fmt.Errorf("get: %w", err)
+	}
+
+	return member, nil
+}
+
+func (s Service) AddMemberRole(ctx context.Context, req request.Request, guildID, userID, roleID string) error {
+	if err := discard(ctx, req.Path("/guilds/%s/members/%s/roles/%s", guildID, userID, roleID).Method(http.MethodPut), nil); err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) RemoveMemberRole(ctx context.Context, req request.Request, guildID, userID, roleID string) error {
+	if err := discard(ctx, req.Path("/guilds/%s/members/%s/roles/%s", guildID, userID, roleID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const stateTag = "state"
+
+var ErrStateMissingField = errors.New("required value is missing")
+
+// StateError describes which field of a state failed to decode
+type StateError struct {
+	Err   error
+	Field string
+}
+
+func (e StateError) Error() string {
+	return fmt.Sprintf("state field `%s`: %s", e.Field, e.Err)
+}
+
+func (e StateError) Unwrap() error {
+	return e.Err
+}
+
+// StateValidator is implemented by states that check their own consistency once decoded
+type StateValidator interface {
+	Validate() error
+}
+
+// EncodeState encodes the given struct into a custom ID, using the `state` tag of its fields
+func EncodeState[T any](ctx context.Context, codec CustomIDCodec, state T) (string, error) {
+	values, err := MarshalState(state)
+	if err != nil {
+		return "", fmt.Errorf("marshal: %w", err)
+	}
+
+	return codec.Encode(ctx, values)
+}
+
+// DecodeState decodes a custom ID produced by EncodeState and validates it
+func DecodeState[T any](ctx context.Context, codec CustomIDCodec, customID string) (T, error) {
+	var output T
+
+	values, err := codec.Decode(ctx, customID)
+	if err != nil {
+		if errors.Is(err, ErrCustomIDExpired) {
+			return output, fmt.Errorf("state is no longer available, please run the command again: %w", err)
+		}
+
+		return output, fmt.Errorf("decode: %w", err)
+	}
+
+	return UnmarshalState[T](values)
+}
+
+// MarshalState converts a struct into values. Fields are named by their `state` tag, skipped with `-` and always written with the `required` option, a required slice being rejected when empty because it can't be written.
+func MarshalState(state any) (url.Values, error) {
+	value := reflect.Indirect(reflect.ValueOf(state))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("state must be a struct, got `%s`", value.Kind())
+	}
+
+	output := url.Values{}
+
+	for field, fieldValue := range value.Fields() {
+		name, required, ok := stateField(field)
+		if !ok {
+			continue
+		}
+
+		if !required && fieldValue.IsZero() {
+			continue
+		}
+
+		if isStringSlice(fieldValue) {
+			if fieldValue.Len() == 0 {
+				return nil, StateError{Field: name, Err: ErrStateMissingField}
+			}
+
+			output[name] = formatStateSlice(fieldValue)
+			continue
+		}
+
+		formatted, err := formatStateValue(fieldValue)
+		if err != nil {
+			return nil, StateError{Field: name, Err: err}
+		}
+
+		output.Set(name, formatted)
+	}
+
+	return output, nil
+}
+
+// UnmarshalState fills a struct from values, checking required fields and calling Validate if implemented
+func UnmarshalState[T any](values url.Values) (T, error) {
+	var output T
+
+	value := reflect.ValueOf(&output).Elem()
+	if value.Kind() != reflect.Struct {
+		return output, fmt.Errorf("state must be a struct, got `%s`", value.Kind())
+	}
+
+	for field, fieldValue := range value.Fields() {
+		name, required, ok := stateField(field)
+		if !ok {
+			continue
+		}
+
+		raw, found := values[name]
+		if !found || len(raw) == 0 {
+			if required {
+				return output, StateError{Field: name, Err: ErrStateMissingField}
+			}
+
+			continue
+		}
+
+		if isStringSlice(fieldValue) {
+			fieldValue.Set(parseStateSlice(fieldValue.Type(), raw))
+			continue
+		}
+
+		if err := parseStateValue(fieldValue, raw[0]); err != nil {
+			return output, StateError{Field: name, Err: err}
+		}
+	}
+
+	if validator, ok := any(&output).(StateValidator); ok {
+		if err := validator.Validate(); err != nil {
+			return output, fmt.Errorf("validate: %w", err)
+		}
+	}
+
+	return output, nil
+}
+
+func stateField(field reflect.StructField) (string, bool, bool) {
+	if !field.IsExported() {
+		return "", false, false
+	}
+
+	tag := field.Tag.Get(stateTag)
+	if tag == "-" {
+		return "", false, false
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	if len(name) == 0 {
+		name = field.Name
+	}
+
+	return name, options == "required", true
+}
+
+// isStringSlice matches slices of strings, including named types like `type Tags []string` or `[]Tag`
+func isStringSlice(value reflect.Value) bool {
+	return value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String
+}
+
+func formatStateSlice(value reflect.Value) []string {
+	output := make([]string, value.Len())
+	for i := range output {
+		output[i] = value.Index(i).String()
+	}
+
+	return output
+}
+
+func parseStateSlice(sliceType reflect.Type, raw []string) reflect.Value {
+	output := reflect.MakeSlice(sliceType, len(raw), len(raw))
+	for i, item := range raw {
+		output.Index(i).SetString(item)
+	}
+
+	return output
+}
+
+func formatStateValue(value reflect.Value) (string, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported type `%s`", value.Type())
+	}
+}
+
+func parseStateValue(value reflect.Value, raw string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		value.SetBool(parsed)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(parsed)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetUint(parsed)
+
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(parsed)
+
+	default:
+		return fmt.Errorf("unsupported type `%s`", value.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type stateTags []string
+
+type stateLabel string
+
+type testState struct {
+	Name     string       `state:"n,required"`
+	Tags     []string     `state:"t"`
+	Named    stateTags    `state:"nt"`
+	Elements []stateLabel `state:"e"`
+	Count    int          `state:"c"`
+	Small    int8         `state:"s"`
+	Size     uint64       `state:"u"`
+	Ratio    float64      `state:"r"`
+	Enabled  bool         `state:"b"`
+	Skipped  string       `state:"-"`
+	Default  string
+	Type     CommandType `state:"ct"`
+	hidden   string
+}
+
+type validatedState struct {
+	Page int `state:"p"`
+}
+
+var errInvalidPage = errors.New("page must be positive")
+
+func (v validatedState) Validate() error {
+	if v.Page < 0 {
+		return errInvalidPage
+	}
+
+	return nil
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]testState{
+		"full": {
+			Name:     "hello world",
+			Tags:     []string{"a", "b|c"},
+			Named:    stateTags{"x", "y"},
+			Elements: []stateLabel{"z"},
+			Count:    -42,
+			Small:    -8,
+			Size:     1 << 63,
+			Ratio:    3.14,
+			Enabled:  true,
+			Default:  "untagged",
+			Type:     MessageCommand,
+		},
+		"required only": {
+			Name: "",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			t.Parallel()
+
+			input := testCase
+			input.Skipped = "ignored"
+			input.hidden = "ignored"
+
+			values, err := MarshalState(input)
+			if err != nil {
+				t.Fatalf("marshal: %s", err)
+			}
+
+			if _, ok := values["n"]; !ok {
+				t.Error("required field is not written when empty")
+			}
+
+			if _, ok := values["-"]; ok {
+				t.Error("skipped field is written")
+			}
+
+			actual, err := UnmarshalState[testState](values)
+			if err != nil {
+				t.Fatalf("unmarshal: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, testCase) {
+				t.Errorf("UnmarshalState(MarshalState()) = %+v, want %+v", actual, testCase)
+			}
+		})
+	}
+}
+
+func TestStateCodecRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	codec, err := NewCustomIDCodec("secret", NewMemoryCustomIDStore(0, 0), 0)
+	if err != nil {
+		t.Fatalf("codec: %s", err)
+	}
+
+	cases := map[string]struct {
+		input      testState
+		wantPrefix string
+	}{
+		"inline": {
+			input: testState{
+				Name:    "a|b=c%d",
+				Tags:    []string{"x|y", "="},
+				Count:   -1,
+				Enabled: true,
+			},
+			wantPrefix: inlineCustomIDPrefix,
+		},
+		"stored": {
+			input: testState{
+				Name: strings.Repeat("long name ", 20),
+				Tags: []string{"x|y"},
+			},
+			wantPrefix: storedCustomIDPrefix,
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			t.Parallel()
+
+			customID, err := EncodeState(context.Background(), codec, testCase.input)
+			if err != nil {
+				t.Fatalf("encode: %s", err)
+			}
+
+			if !strings.HasPrefix(customID, testCase.wantPrefix) {
+				t.Errorf("EncodeState() = `%s`, want prefix `%s`", customID, testCase.wantPrefix)
+			}
+
+			actual, err := DecodeState[testState](context.Background(), codec, customID)
+			if err != nil {
+				t.Fatalf("decode: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, testCase.input) {
+				t.Errorf("DecodeState(EncodeState()) = %+v, want %+v", actual, testCase.input)
+			}
+		})
+	}
+}
+
+func TestMarshalState(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		input   any
+		wantErr bool
+	}{
+		"pointer": {
+			input: &validatedState{Page: 1},
+		},
+		"not a struct": {
+			input:   "state",
+			wantErr: true,
+		},
+		"unsupported type": {
+			input: struct {
+				Values map[string]int `state:"v"`
+			}{Values: map[string]int{"a": 1}},
+			wantErr: true,
+		},
+		"empty required slice": {
+			input: struct {
+				Tags []string `state:"t,required"`
+			}{Tags: []string{}},
+			wantErr: true,
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := MarshalState(testCase.input)
+			if (err != nil) != testCase.wantErr {
+				t.Errorf("MarshalState() error = %v, wantErr %t", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnmarshalState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing required", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := UnmarshalState[testState](url.Values{"c": {"1"}})
+
+		var stateErr StateError
+		if !errors.As(err, &stateErr) || stateErr.Field != "n" || !errors.Is(err, ErrStateMissingField) {
+			t.Errorf("UnmarshalState() error = %v, want missing `n`", err)
+		}
+	})
+
+	t.Run("missing optional", func(t *testing.T) {
+		t.Parallel()
+
+		actual, err := UnmarshalState[testState](url.Values{"n": {"name"}})
+		if err != nil {
+			t.Fatalf("unmarshal: %s", err)
+		}
+
+		if !reflect.DeepEqual(actual, testState{Name: "name"}) {
+			t.Errorf("UnmarshalState() = %+v, want only name", actual)
+		}
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := UnmarshalState[testState](url.Values{"n": {"name"}, "s": {"1000"}})
+
+		var stateErr StateError
+		if !errors.As(err, &stateErr) || stateErr.Field != "s" {
+			t.Errorf("UnmarshalState() error = %v, want invalid `s`", err)
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := UnmarshalState[validatedState](url.Values{"p": {"-1"}}); !errors.Is(err, errInvalidPage) {
+			t.Errorf("UnmarshalState() error = %v, want %v", err, errInvalidPage)
+		}
+
+		if actual, err := UnmarshalState[validatedState](url.Values{"p": {"2"}}); err != nil || actual.Page != 2 {
+			t.Errorf("UnmarshalState() = (%+v, %v), want page 2", actual, err)
+		}
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := UnmarshalState[string](url.Values{}); err == nil {
+			t.Error("UnmarshalState() expected an error")
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package discord
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const memoryPurgeInterval = time.Minute
+
+// CustomIDStore persists `custom_id` contents that don't fit in the 100 characters limit. Load returns ErrCustomIDExpired when key is unknown.
+type CustomIDStore interface {
+	Save(ctx context.Context, key, content string, ttl time.Duration) error
+	Load(ctx context.Context, key string) (string, error)
+	Delete(ctx context.Context, keys ...string) error
+}
+
+type memoryEntry struct {
+	expireAt time.Time
+	key      string
+	content  string
+}
+
+// MemoryCustomIDStore is an in-process store, evicting least recently used entries when capacity is reached
+type MemoryCustomIDStore struct {
+	lastPurge time.Time
+	entries   map[string]*list.Element
+	order     *list.List
+	mutex     sync.Mutex
+	sliding   time.Duration
+	capacity  int
+}
+
+// NewMemoryCustomIDStore creates an in-process store. When sliding is positive, each load resets the entry's expiration to this duration, like NewRedisCustomIDStore.
+func NewMemoryCustomIDStore(capacity int, sliding time.Duration) *MemoryCustomIDStore {
+	return &MemoryCustomIDStore{
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		capacity: capacity,
+		sliding:  sliding,
+	}
+}
+
+func (m *MemoryCustomIDStore) Save(_ context.Context, key, content string, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	m.purge(now)
+
+	entry := memoryEntry{
+		key:      key,
+		content:  content,
+		expireAt: now.Add(ttl),
+	}
+
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(entry)
+
+	for m.capacity > 0 && m.order.Len() > m.capacity {
+		m.remove(m.order.Back())
+	}
+
+	return nil
+}
+
+func (m *MemoryCustomIDStore) Load(_ context.Context, key string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return "", ErrCustomIDExpired
+	}
+
+	entry := element.Value.(memoryEntry)
+
+	now := time.Now()
+	if now.After(entry.expireAt) {
+		m.remove(element)
+		return "", ErrCustomIDExpired
+	}
+
+	if m.sliding > 0 {
+		entry.expireAt = now.Add(m.sliding)
+		element.Value = entry
+	}
+
+	m.order.MoveToFront(element)
+
+	return entry.content, nil
+}
+
+func (m *MemoryCustomIDStore) Delete(_ context.Context, keys ...string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, key := range keys {
+		if element, ok := m.entries[key]; ok {
+			m.remove(element)
+		}
+	}
+
+	return nil
+}
+
+// purge removes expired entries, at most once per interval to keep saves cheap
+func (m *MemoryCustomIDStore) purge(now time.Time) {
+	if now.Sub(m.lastPurge) < memoryPurgeInterval {
+		return
+	}
+
+	m.lastPurge = now
+
+	for element := m.order.Front(); element != nil; {
+		next := element.Next()
+
+		if now.After(element.Value.(memoryEntry).expireAt) {
+			m.remove(element)
+		}
+
+		element = next
+	}
+}
+
+func (m *MemoryCustomIDStore) remove(element *list.Element) {
+	delete(m.entries, element.Value.(memoryEntry).key)
+	m.order.Remove(element)
+}
@@ -0,0 +1,144 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ViBiOh/httputils/v4/pkg/httpjson"
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+const threadsPageSize = 100
+
+type threadsList struct {
+	Threads []Channel `json:"threads"`
+	HasMore bool      `json:"has_more"`
+}
+
+type threadPayload struct {
+	Message             *InteractionDataResponse `json:"message,omitempty"`
+	Name                string                   `json:"name"`
+	AppliedTags         []string                 `json:"applied_tags,omitempty"`
+	Type                ChannelType              `json:"type,omitempty"`
+	AutoArchiveDuration int                      `json:"auto_archive_duration,omitempty"`
+}
+
+// ActiveThreads lists all active threads of the guild, including forum posts
+func (s Service) ActiveThreads(ctx context.Context, req request.Request, guildID string) ([]Channel, error) {
+	output, err := read[threadsList](ctx, req.Path("/guilds/%s/threads/active", guildID).Method(http.MethodGet), nil)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return output.Threads, nil
+}
+
+// ArchivedThreads lists all archived threads of the channel, browsing every page
+func (s Service) ArchivedThreads(ctx context.Context, req request.Request, channelID string, private bool) ([]Channel, error) {
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+
+	baseURL := fmt.Sprintf("/channels/%s/threads/archived/%s", channelID, visibility)
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(threadsPageSize))
+
+	var output []Channel
+
+	for {
+		page, err := read[threadsList](ctx, req.Path(baseURL+"?"+query.Encode()).Method(http.MethodGet), nil)
+		if err != nil {
+			return output, fmt.Errorf("list: %w", err)
+		}
+
+		output = append(output, page.Threads...)
+
+		if !page.HasMore || len(page.Threads) == 0 {
+			return output, nil
+		}
+
+		last := page.Threads[len(page.Threads)-1]
+		if last.ThreadMetadata == nil {
+			return output, nil
+		}
+
+		query.Set("before", last.ThreadMetadata.ArchiveTimestamp.Format(time.RFC3339))
+	}
+}
+
+// StartThread creates a thread without any starter message
+func (s Service) StartThread(ctx context.Context, req request.Request, channelID, name string, threadType ChannelType) (Channel, error) {
+	output, err := read[Channel](ctx, req.Path("/channels/%s/threads", channelID).Method(http.MethodPost), threadPayload{
+		Name: name,
+		Type: threadType,
+	})
+	if err != nil {
+		return output, fmt.Errorf("create: %w", err)
+	}
+
+	return output, nil
+}
+
+// StartThreadFromMessage creates a thread attached to the given message
+func (s Service) StartThreadFromMessage(ctx context.Context, req request.Request, message Message, name string) (Channel, error) {
+	output, err := read[Channel](ctx, req.Path("/channels/%s/messages/%s/threads", message.ChannelID, message.ID).Method(http.MethodPost), threadPayload{
+		Name: name,
+	})
+	if err != nil {
+		return output, fmt.Errorf("create: %w", err)
+	}
+
+	return output, nil
+}
+
+// CreateForumPost creates a thread in a forum or media channel, with the given starter message
+func (s Service) CreateForumPost(ctx context.Context, req request.Request, channelID, name string, data InteractionDataResponse, tags ...string) (Channel, error) {
+	data = s.prepare(ctx, data)
+
+	payload := threadPayload{
+		Name:        name,
+		AppliedTags: tags,
+		Message:     &data,
+	}
+
+	resp, err := sendPayload(ctx, req.Path("/channels/%s/threads", channelID).Method(http.MethodPost), payload, data)
+	if err != nil {
+		return Channel{}, fmt.Errorf("create: %w", err)
+	}
+
+	return httpjson.Read[Channel](resp)
+}
+
+func (s Service) JoinThread(ctx context.Context, req request.Request, thread Channel) error {
+	if err := discard(ctx, req.Path("/channels/%s/thread-members/@me", thread.ID).Method(http.MethodPut), nil); err != nil {
+		return fmt.Errorf("join: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) LeaveThread(ctx context.Context, req request.Request, thread Channel) error {
+	if err := discard(ctx, req.Path("/channels/%s/thread-members/@me", thread.ID).Method(http.MethodDelete), nil); err != nil {
+		return fmt.Errorf("leave: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) ArchiveThread(ctx context.Context, req request.Request, thread Channel, locked bool) (Channel, error) {
+	output, err := read[Channel](ctx, req.Path("/channels/%s", thread.ID).Method(http.MethodPatch), map[string]bool{
+		"archived": true,
+		"locked":   locked,
+	})
+	if err != nil {
+		return output, fmt.Errorf("archive: %w", err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,371 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxContentLength     = 2000
+	maxEmbeds            = 10
+	maxEmbedTitle        = 256
+	maxEmbedDescription  = 4096
+	maxEmbedFields       = 25
+	maxEmbedFieldName    = 256
+	maxEmbedFieldValue   = 1024
+	maxEmbedAuthorName   = 256
+	maxEmbedFooterText   = 2048
+	maxEmbedsTotalLength = 6000
+	maxAttachments       = 10
+	maxButtonLabel       = 80
+	truncateSuffix       = "…"
+	truncateSuffixLength = 1
+)
+
+// ValidationError describes a part of a payload that Discord would reject
+type ValidationError struct {
+	Field  string
+	Reason string
+	Limit  int
+	Actual int
+}
+
+func (e ValidationError) Error() string {
+	if len(e.Reason) != 0 {
+		return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+	}
+
+	return fmt.Sprintf("%s: %d exceeds limit of %d", e.Field, e.Actual, e.Limit)
+}
+
+// ValidationErrors lists every part of a payload that Discord would reject
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	output := make([]string, len(e))
+	for i, err := range e {
+		output[i] = err.Error()
+	}
+
+	return strings.Join(output, "; ")
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) limit(field string, actual, limit int) {
+	if actual > limit {
+		v.errs = append(v.errs, ValidationError{Field: field, Limit: limit, Actual: actual})
+	}
+}
+
+func (v *validator) length(field, value string, limit int) int {
+	length := utf8.RuneCountInString(value)
+	v.limit(field, length, limit)
+
+	return length
+}
+
+func (v *validator) required(field, value string) {
+	if len(strings.TrimSpace(value)) == 0 {
+		v.errs = append(v.errs, ValidationError{Field: field, Reason: "must not be empty"})
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+// Validate checks the response against Discord's limits, returning ValidationErrors if any
+func (i InteractionResponse) Validate() error {
+	return i.Data.Validate()
+}
+
+// Validate checks the payload against Discord's limits, returning ValidationErrors if any
+func (d InteractionDataResponse) Validate() error {
+	var v validator
+
+	v.length("content", d.Content, maxContentLength)
+	v.limit("embeds", len(d.Embeds), maxEmbeds)
+	v.limit("attachments", len(d.Attachments), maxAttachments)
+
+	var total int
+	for i, embed := range d.Embeds {
+		total += embed.validate(&v, fmt.Sprintf("embeds[%d]", i))
+	}
+
+	v.limit("embeds", total, maxEmbedsTotalLength)
+
+	if d.Poll != nil {
+		d.Poll.validate(&v)
+	}
+
+	for i, component := range d.Components {
+		validateLabels(&v, fmt.Sprintf("components[%d]", i), component)
+	}
+
+	if err := d.CheckComponents(); err != nil {
+		for _, componentErr := range unwrapErrors(err) {
+			v.errs = append(v.errs, ValidationError{Field: "components", Reason: componentErr.Error()})
+		}
+	}
+
+	return v.err()
+}
+
+func (e Embed) validate(v *validator, path string) int {
+	total := v.length(path+".title", e.Title, maxEmbedTitle)
+	total += v.length(path+".description", e.Description, maxEmbedDescription)
+
+	if e.Author != nil {
+		total += v.length(path+".author.name", e.Author.Name, maxEmbedAuthorName)
+	}
+
+	if e.Footer != nil {
+		total += v.length(path+".footer.text", e.Footer.Text, maxEmbedFooterText)
+	}
+
+	v.limit(path+".fields", len(e.Fields), maxEmbedFields)
+
+	for i, field := range e.Fields {
+		namePath, valuePath := fmt.Sprintf("%s.fields[%d].name", path, i), fmt.Sprintf("%s.fields[%d].value", path, i)
+
+		v.required(namePath, field.Name)
+		v.required(valuePath, field.Value)
+
+		total += v.length(namePath, field.Name, maxEmbedFieldName)
+		total += v.length(valuePath, field.Value, maxEmbedFieldValue)
+	}
+
+	return total
+}
+
+func (p Poll) validate(v *validator) {
+	v.length("poll.question", p.Question.Text, maxPollQuestion)
+	v.limit("poll.answers", len(p.Answers), maxPollAnswers)
+	v.limit("poll.duration", p.Duration, maxPollDuration)
+
+	for i, answer := range p.Answers {
+		v.length(fmt.Sprintf("poll.answers[%d]", i), answer.PollMedia.Text, maxPollAnswerText)
+	}
+}
+
+func validateLabels(v *validator, path string, component Component) {
+	if component.Type == buttonType {
+		v.length(path+".label", component.Label, maxButtonLabel)
+	}
+
+	if component.Accessory != nil {
+		validateLabels(v, path+".accessory", *component.Accessory)
+	}
+
+	for i, child := range component.Components {
+		validateLabels(v, fmt.Sprintf("%s.components[%d]", path, i), child)
+	}
+}
+
+func unwrapErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var output []error
+
+		for _, child := range joined.Unwrap() {
+			output = append(output, unwrapErrors(child)...)
+		}
+
+		return output
+	}
+
+	return []error{err}
+}
+
+// Truncate trims the response so it fits Discord's length limits
+func (i InteractionResponse) Truncate() InteractionResponse {
+	i.Data = i.Data.Truncate()
+	return i
+}
+
+// Truncate trims texts, and drops extra embeds, fields, attachments, action rows and poll answers, so the payload fits Discord's limits
+func (d InteractionDataResponse) Truncate() InteractionDataResponse {
+	d.Content = truncate(d.Content, maxContentLength)
+
+	if len(d.Embeds) > maxEmbeds {
+		d.Embeds = d.Embeds[:maxEmbeds]
+	}
+
+	if len(d.Attachments) > maxAttachments {
+		d.Attachments = d.Attachments[:maxAttachments]
+	}
+
+	if d.Flags&ComponentsV2Message == 0 && len(d.Components) > maxActionRowComponents {
+		d.Components = d.Components[:maxActionRowComponents]
+	}
+
+	d.Components = truncateLabels(d.Components)
+
+	if d.Poll != nil {
+		poll := d.Poll.truncate()
+		d.Poll = &poll
+	}
+
+	if d.Embeds != nil {
+		embeds := make([]Embed, 0, len(d.Embeds))
+		budget := maxEmbedsTotalLength
+
+		for _, embed := range d.Embeds {
+			if budget <= 0 {
+				break
+			}
+
+			embed, budget = embed.truncate(budget)
+			embeds = append(embeds, embed)
+		}
+
+		d.Embeds = embeds
+	}
+
+	return d
+}
+
+// truncateLabels trims button labels on copies, leaving the caller's components untouched
+func truncateLabels(components []Component) []Component {
+	if components == nil {
+		return nil
+	}
+
+	output := make([]Component, len(components))
+
+	for i, component := range components {
+		if component.Type == buttonType {
+			component.Label = truncate(component.Label, maxButtonLabel)
+		}
+
+		if component.Accessory != nil {
+			accessory := truncateLabels([]Component{*component.Accessory})[0]
+			component.Accessory = &accessory
+		}
+
+		component.Components = truncateLabels(component.Components)
+		output[i] = component
+	}
+
+	return output
+}
+
+func (p Poll) truncate() Poll {
+	p.Question.Text = truncate(p.Question.Text, maxPollQuestion)
+	p.Duration = min(p.Duration, maxPollDuration)
+
+	if p.Answers != nil {
+		answers := make([]PollAnswer, 0, min(len(p.Answers), maxPollAnswers))
+
+		for _, answer := range p.Answers[:cap(answers)] {
+			answer.PollMedia.Text = truncate(answer.PollMedia.Text, maxPollAnswerText)
+			answers = append(answers, answer)
+		}
+
+		p.Answers = answers
+	}
+
+	return p
+}
+
+func (e Embed) truncate(budget int) (Embed, int) {
+	fit := func(value string, limit int) string {
+		value = truncate(value, min(limit, budget))
+		budget -= utf8.RuneCountInString(value)
+
+		return value
+	}
+
+	e.Title = fit(e.Title, maxEmbedTitle)
+
+	if e.Author != nil {
+		author := *e.Author
+		author.Name = fit(author.Name, maxEmbedAuthorName)
+		e.Author = &author
+	}
+
+	if e.Footer != nil {
+		footer := *e.Footer
+		footer.Text = fit(footer.Text, maxEmbedFooterText)
+		e.Footer = &footer
+	}
+
+	e.Description = fit(e.Description, maxEmbedDescription)
+
+	if e.Fields != nil {
+		fields := make([]Field, 0, min(len(e.Fields), maxEmbedFields))
+
+		for _, field := range e.Fields {
+			if len(fields) == maxEmbedFields || budget < 2 {
+				break
+			}
+
+			// a field is kept only if both its name and value keep at least one character, Discord rejecting empty ones
+			field.Name = truncate(field.Name, min(maxEmbedFieldName, budget-1))
+			field.Value = truncate(field.Value, min(maxEmbedFieldValue, budget-utf8.RuneCountInString(field.Name)))
+
+			if len(strings.TrimSpace(field.Name)) == 0 || len(strings.TrimSpace(field.Value)) == 0 {
+				continue
+			}
+
+			budget -= utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+			fields = append(fields, field)
+		}
+
+		e.Fields = fields
+	}
+
+	return e, budget
+}
+
+func truncate(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+
+	if limit <= truncateSuffixLength {
+		return string([]rune(value)[:max(limit, 0)])
+	}
+
+	return string([]rune(value)[:limit-truncateSuffixLength]) + truncateSuffix
+}
+
+// WithTruncation makes the service trim responses exceeding Discord's limits instead of sending them as-is
+func (s Service) WithTruncation() Service {
+	s.truncate = true
+	return s
+}
+
+func (s Service) prepare(ctx context.Context, data InteractionDataResponse) InteractionDataResponse {
+	return prepare(ctx, data, s.truncate)
+}
+
+func prepare(ctx context.Context, data InteractionDataResponse, truncation bool) InteractionDataResponse {
+	err := data.Validate()
+	if err == nil {
+		return data
+	}
+
+	slog.LogAttrs(ctx, slog.LevelWarn, "response exceeds Discord limits", slog.Bool("truncate", truncation), slog.Any("error", err))
+
+	if !truncation {
+		return data
+	}
+
+	data = data.Truncate()
+
+	if err := data.Validate(); err != nil {
+		slog.LogAttrs(ctx, slog.LevelWarn, "truncated response is still invalid", slog.Any("error", err))
+	}
+
+	return data
+}
@@ -0,0 +1,156 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ViBiOh/httputils/v4/pkg/httpjson"
+	"github.com/ViBiOh/httputils/v4/pkg/request"
+)
+
+var ErrInvalidWebhookURL = errors.New("invalid webhook url")
+
+// Webhook executes an incoming webhook of a channel, without requiring a bot token
+type Webhook struct {
+	id        string
+	token     string
+	username  string
+	avatarURL string
+	threadID  string
+	truncate  bool
+}
+
+type webhookPayload struct {
+	InteractionDataResponse
+	Username  string `json:"username,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// NewWebhook parses a webhook URL like https://discord.com/api/webhooks/{id}/{token}
+func NewWebhook(rawURL string) (Webhook, error) {
+	webhookURL, err := url.Parse(rawURL)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("parse: %w", err)
+	}
+
+	_, credentials, ok := strings.Cut(webhookURL.Path, "/webhooks/")
+	if !ok {
+		return Webhook{}, ErrInvalidWebhookURL
+	}
+
+	id, token, ok := strings.Cut(strings.Trim(credentials, "/"), "/")
+	if !ok || len(id) == 0 || len(token) == 0 || strings.Contains(token, "/") {
+		return Webhook{}, ErrInvalidWebhookURL
+	}
+
+	return Webhook{
+		id:       id,
+		token:    token,
+		threadID: webhookURL.Query().Get("thread_id"),
+	}, nil
+}
+
+// WithIdentity overrides the username and avatar configured on the webhook
+func (w Webhook) WithIdentity(username, avatarURL string) Webhook {
+	w.username = username
+	w.avatarURL = avatarURL
+
+	return w
+}
+
+// WithTruncation truncates content exceeding Discord limits instead of sending it as is
+func (w Webhook) WithTruncation() Webhook {
+	w.truncate = true
+
+	return w
+}
+
+// InThread targets a thread of the webhook's channel
+func (w Webhook) InThread(threadID string) Webhook {
+	w.threadID = threadID
+
+	return w
+}
+
+// Send executes the webhook without waiting for the message to be created
+func (w Webhook) Send(ctx context.Context, data InteractionDataResponse) error {
+	resp, err := w.execute(ctx, w.request(http.MethodPost, "", false), data)
+	if err != nil {
+		return fmt.Errorf("execute: %w", err)
+	}
+
+	return request.DiscardBody(resp.Body)
+}
+
+// Execute executes the webhook and returns the created message
+func (w Webhook) Execute(ctx context.Context, data InteractionDataResponse) (Message, error) {
+	resp, err := w.execute(ctx, w.request(http.MethodPost, "", true), data)
+	if err != nil {
+		return Message{}, fmt.Errorf("execute: %w", err)
+	}
+
+	return httpjson.Read[Message](resp)
+}
+
+// EditMessage edits a message previously sent by the webhook
+func (w Webhook) EditMessage(ctx context.Context, messageID string, data InteractionDataResponse) (Message, error) {
+	resp, err := w.edit(ctx, w.request(http.MethodPatch, messageID, false), data)
+	if err != nil {
+		return Message{}, fmt.Errorf("edit: %w", err)
+	}
+
+	return httpjson.Read[Message](resp)
+}
+
+// DeleteMessage deletes a message previously sent by the webhook
+func (w Webhook) DeleteMessage(ctx context.Context, messageID string) error {
+	if err := discard(ctx, w.request(http.MethodDelete, messageID, false), nil); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+func (w Webhook) request(method, messageID string, wait bool) request.Request {
+	query := url.Values{}
+
+	if wait {
+		query.Set("wait", "true")
+	}
+
+	if len(w.threadID) != 0 {
+		query.Set("thread_id", w.threadID)
+	}
+
+	path := fmt.Sprintf("/webhooks/%s/%s", url.PathEscape(w.id), url.PathEscape(w.token))
+	if len(messageID) != 0 {
+		path += "/messages/" + url.PathEscape(messageID)
+	}
+
+	if len(query) != 0 {
+		path += "?" + query.Encode()
+	}
+
+	return discordRequest.Method(method).Path(path)
+}
+
+func (w Webhook) execute(ctx context.Context, req request.Request, data InteractionDataResponse) (*http.Response, error) {
+	data = prepare(ctx, data, w.truncate)
+
+	return sendPayload(ctx, req, webhookPayload{
+		InteractionDataResponse: data,
+		Username:                w.username,
+		AvatarURL:               w.avatarURL,
+	}, data)
+}
+
+// edit sends the data alone, the identity can't be changed once the message is created
+func (w Webhook) edit(ctx context.Context, req request.Request, data InteractionDataResponse) (*http.Response, error) {
+	data = prepare(ctx, data, w.truncate)
+
+	return sendPayload(ctx, req, data, data)
+}
@@ -187,6 +187,7 @@ type SlashPayload struct {
 	ResponseURL string `json:"response_url"`
 	Text        string `json:"text"`
 	Token       string `json:"token"`
+	TriggerID   string `json:"trigger_id"`
 	UserID      string `json:"user_id"`
 }
 
@@ -206,6 +207,7 @@ type InteractivePayload struct {
 	} `json:"container"`
 	Type        string              `json:"type"`
 	ResponseURL string              `json:"response_url"`
+	TriggerID   string              `json:"trigger_id"`
 	Actions     []InteractiveAction `json:"actions"`
 }
 
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ViBiOh/ChatPotte/dedup"
 	"github.com/ViBiOh/flags"
 	"github.com/ViBiOh/httputils/v4/pkg/httperror"
 	"github.com/ViBiOh/httputils/v4/pkg/httpjson"
@@ -40,10 +41,12 @@ type Service struct {
 	tracer     trace.Tracer
 	onCommand  CommandHandler
 	onInteract InteractHandler
+	dedup      dedup.Deduplicator
 
 	clientID      string
 	clientSecret  string
 	signingSecret []byte
+	truncate      bool
 }
 
 func Flags(fs *flag.FlagSet, prefix string, overrides ...flags.Override) *Config {
@@ -107,10 +110,53 @@ func (s Service) handleCommand(w http.ResponseWriter, r *http.Request) {
 		ResponseURL: r.FormValue("response_url"),
 		Text:        r.FormValue("text"),
 		Token:       r.FormValue("token"),
+		TriggerID:   r.FormValue("trigger_id"),
 		UserID:      r.FormValue("user_id"),
 	}
 
-	httpjson.Write(ctx, w, http.StatusOK, s.onCommand(ctx, payload))
+	if cached, duplicate := s.isDuplicate(r, payload.TriggerID); duplicate {
+		dedup.WriteDuplicate(w, cached)
+		return
+	}
+
+	var done bool
+	defer func() {
+		if !done {
+			s.dedup.Release(ctx, payload.TriggerID)
+		}
+	}()
+
+	response := s.prepare(ctx, s.onCommand(ctx, payload))
+	httpjson.Write(ctx, w, http.StatusOK, response)
+
+	s.dedup.Done(ctx, payload.TriggerID, response)
+	done = true
+}
+
+// WithDeduplication answers retried requests with the response of the first one, without calling the handler again
+func (s Service) WithDeduplication(deduplicator dedup.Deduplicator) Service {
+	s.dedup = deduplicator
+	return s
+}
+
+// isDuplicate deduplicates on the trigger ID. A retry without one is processed, as it can't be matched with the first request.
+func (s Service) isDuplicate(r *http.Request, triggerID string) ([]byte, bool) {
+	retryNum := r.Header.Get("X-Slack-Retry-Num")
+
+	if len(triggerID) == 0 {
+		if len(retryNum) != 0 {
+			slog.LogAttrs(r.Context(), slog.LevelWarn, "retried request without trigger ID", slog.String("retry_num", retryNum), slog.String("reason", r.Header.Get("X-Slack-Retry-Reason")))
+		}
+
+		return nil, false
+	}
+
+	cached, duplicate := s.dedup.Claim(r.Context(), triggerID)
+	if duplicate {
+		slog.LogAttrs(r.Context(), slog.LevelWarn, "duplicate request", slog.String("trigger_id", triggerID), slog.String("retry_num", retryNum))
+	}
+
+	return cached, duplicate
 }
 
 func (s Service) checkSignature(r *http.Request) bool {
@@ -165,6 +211,11 @@ func (s Service) handleInteract(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, duplicate := s.isDuplicate(r, payload.TriggerID); duplicate {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 
 	go func(ctx context.Context) {
@@ -173,12 +224,25 @@ func (s Service) handleInteract(w http.ResponseWriter, r *http.Request) {
 		ctx, end := telemetry.StartSpan(ctx, s.tracer, "async_intereact")
 		defer end(&err)
 
-		slackResponse := s.onInteract(ctx, payload)
+		var done bool
+		defer func() {
+			if !done {
+				s.dedup.Release(ctx, payload.TriggerID)
+			}
+		}()
+
+		slackResponse := s.prepare(ctx, s.onInteract(ctx, payload))
 
 		resp, err := request.Post(payload.ResponseURL).StreamJSON(ctx, slackResponse)
 		if err != nil {
 			slog.LogAttrs(ctx, slog.LevelError, "send interact on response_url", slog.Any("error", err))
-		} else if discardErr := request.DiscardBody(resp.Body); discardErr != nil {
+			return
+		}
+
+		s.dedup.Done(ctx, payload.TriggerID, slackResponse)
+		done = true
+
+		if discardErr := request.DiscardBody(resp.Body); discardErr != nil {
 			slog.LogAttrs(ctx, slog.LevelError, "discard interact body on response_url", slog.Any("error", err))
 		}
 	}(context.WithoutCancel(ctx))
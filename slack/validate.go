@@ -0,0 +1,240 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxTextLength        = 40000
+	maxBlocks            = 50
+	maxSectionText       = 3000
+	maxSectionFields     = 10
+	maxSectionFieldText  = 2000
+	maxActionsElements   = 25
+	maxContextElements   = 10
+	maxButtonText        = 75
+	maxActionIDLength    = 255
+	maxBlockIDLength     = 255
+	maxButtonValue       = 2000
+	maxImageAltText      = 2000
+	maxImageTitle        = 2000
+	truncateSuffix       = "…"
+	truncateSuffixLength = 1
+)
+
+// ValidationError describes a part of a response that Slack would reject
+type ValidationError struct {
+	Field  string
+	Limit  int
+	Actual int
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %d exceeds limit of %d", e.Field, e.Actual, e.Limit)
+}
+
+// ValidationErrors lists every part of a response that Slack would reject
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	output := make([]string, len(e))
+	for i, err := range e {
+		output[i] = err.Error()
+	}
+
+	return strings.Join(output, "; ")
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) limit(field string, actual, limit int) {
+	if actual > limit {
+		v.errs = append(v.errs, ValidationError{Field: field, Limit: limit, Actual: actual})
+	}
+}
+
+func (v *validator) length(field, value string, limit int) {
+	v.limit(field, utf8.RuneCountInString(value), limit)
+}
+
+// Validate checks the response against Slack's limits, returning ValidationErrors if any
+func (r Response) Validate() error {
+	var v validator
+
+	v.length("text", r.Text, maxTextLength)
+	v.limit("blocks", len(r.Blocks), maxBlocks)
+
+	for i, block := range r.Blocks {
+		path := fmt.Sprintf("blocks[%d]", i)
+
+		switch content := block.(type) {
+		case Section:
+			v.length(path+".text", content.Text.Text, maxSectionText)
+			v.limit(path+".fields", len(content.Fields), maxSectionFields)
+
+			for j, field := range content.Fields {
+				v.length(fmt.Sprintf("%s.fields[%d]", path, j), field.Text, maxSectionFieldText)
+			}
+
+			if content.Accessory != nil {
+				v.length(path+".accessory.alt_text", content.Accessory.Alt, maxImageAltText)
+			}
+
+		case Actions:
+			v.length(path+".block_id", content.BlockID, maxBlockIDLength)
+			v.limit(path+".elements", len(content.Elements), maxActionsElements)
+			validateElements(&v, path, content.Elements)
+
+		case Context:
+			v.limit(path+".elements", len(content.Elements), maxContextElements)
+			validateElements(&v, path, content.Elements)
+
+		case Image:
+			v.length(path+".alt_text", content.Alt, maxImageAltText)
+			v.length(path+".title", content.Title.Text, maxImageTitle)
+		}
+	}
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+func validateElements(v *validator, path string, elements []Element) {
+	for i, element := range elements {
+		elementPath := fmt.Sprintf("%s.elements[%d]", path, i)
+
+		switch content := element.(type) {
+		case ButtonElement:
+			v.length(elementPath+".text", content.Text.Text, maxButtonText)
+			v.length(elementPath+".action_id", content.ActionID, maxActionIDLength)
+			v.length(elementPath+".value", content.Value, maxButtonValue)
+
+		case Text:
+			v.length(elementPath+".text", content.Text, maxSectionText)
+		}
+	}
+}
+
+// Truncate trims texts and drops extra blocks, fields and elements, so the response fits Slack's limits
+func (r Response) Truncate() Response {
+	r.Text = truncate(r.Text, maxTextLength)
+
+	if len(r.Blocks) > maxBlocks {
+		r.Blocks = r.Blocks[:maxBlocks]
+	}
+
+	if r.Blocks == nil {
+		return r
+	}
+
+	blocks := make([]Block, len(r.Blocks))
+
+	for i, block := range r.Blocks {
+		switch content := block.(type) {
+		case Section:
+			content.Text.Text = truncate(content.Text.Text, maxSectionText)
+
+			if len(content.Fields) > maxSectionFields {
+				content.Fields = content.Fields[:maxSectionFields]
+			}
+
+			if content.Fields != nil {
+				fields := make([]Text, len(content.Fields))
+				for j, field := range content.Fields {
+					field.Text = truncate(field.Text, maxSectionFieldText)
+					fields[j] = field
+				}
+
+				content.Fields = fields
+			}
+
+			block = content
+
+		case Actions:
+			content.Elements = truncateElements(content.Elements, maxActionsElements)
+			block = content
+
+		case Context:
+			content.Elements = truncateElements(content.Elements, maxContextElements)
+			block = content
+		}
+
+		blocks[i] = block
+	}
+
+	r.Blocks = blocks
+
+	return r
+}
+
+func truncateElements(elements []Element, limit int) []Element {
+	if len(elements) > limit {
+		elements = elements[:limit]
+	}
+
+	output := make([]Element, len(elements))
+
+	for i, element := range elements {
+		switch content := element.(type) {
+		case ButtonElement:
+			content.Text.Text = truncate(content.Text.Text, maxButtonText)
+			element = content
+
+		case Text:
+			content.Text = truncate(content.Text, maxSectionText)
+			element = content
+		}
+
+		output[i] = element
+	}
+
+	return output
+}
+
+func truncate(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+
+	if limit <= truncateSuffixLength {
+		return string([]rune(value)[:max(limit, 0)])
+	}
+
+	return string([]rune(value)[:limit-truncateSuffixLength]) + truncateSuffix
+}
+
+// WithTruncation makes the service trim responses exceeding Slack's limits instead of sending them as-is
+func (s Service) WithTruncation() Service {
+	s.truncate = true
+	return s
+}
+
+func (s Service) prepare(ctx context.Context, response Response) Response {
+	err := response.Validate()
+	if err == nil {
+		return response
+	}
+
+	slog.LogAttrs(ctx, slog.LevelWarn, "response exceeds Slack limits", slog.Bool("truncate", s.truncate), slog.Any("error", err))
+
+	if !s.truncate {
+		return response
+	}
+
+	response = response.Truncate()
+
+	if err := response.Validate(); err != nil {
+		slog.LogAttrs(ctx, slog.LevelWarn, "truncated response is still invalid", slog.Any("error", err))
+	}
+
+	return response
+}